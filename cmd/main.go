@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,6 +14,14 @@ import (
 )
 
 func main() {
+	// config print shows the effective config, e.g. to check the overrides of the environment variables.
+	if len(os.Args) == 3 && os.Args[1] == "config" && os.Args[2] == "print" {
+		if err := printConfig(); err != nil {
+			log.Fatalf("Print config failed: %v", err)
+		}
+		return
+	}
+
 	c, err := loadConfig()
 	if err != nil {
 		log.Fatalf("Load config failed: %v", err)
@@ -27,12 +37,17 @@ func main() {
 
 	go s.Start()
 
+	// The reloadable settings are applied when the config file changes or on SIGHUP.
+	ctx, stopWatch := context.WithCancel(context.Background())
+	go config.Watch(ctx, os.Getenv("CONFIG_PATH"), func() { reloadConfig(s) })
+
 	<-shutdown
+	stopWatch()
 	s.Shutdown()
 }
 
 func loadConfig() (server.Config, error) {
-	var c server.Config
+	c := server.DefaultConfig()
 
 	p := os.Getenv("CONFIG_PATH")
 	if p == "" {
@@ -45,3 +60,26 @@ func loadConfig() (server.Config, error) {
 
 	return c, nil
 }
+
+func reloadConfig(s *server.Server) {
+	c, err := loadConfig()
+	if err != nil {
+		// The running config is kept.
+		slog.Error("main: reload config failed", "error", err)
+		return
+	}
+
+	// The rejected reloads are logged by the server.
+	_ = s.Reload(c)
+}
+
+func printConfig() error {
+	c := server.DefaultConfig()
+
+	p := os.Getenv("CONFIG_PATH")
+	if p == "" {
+		return fmt.Errorf("CONFIG_PATH not set")
+	}
+
+	return config.Print(os.Stdout, p, &c)
+}
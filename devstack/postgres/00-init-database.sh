@@ -31,6 +31,19 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "quiz_sessions" <<-
       PRIMARY KEY (session_id, question_id),
       FOREIGN KEY (session_id) REFERENCES sessions(session_id)
     );
+
+    CREATE TABLE webhook_deliveries (
+      delivery_id UUID NOT NULL,
+      attempt INT NOT NULL,
+      event_id TEXT NOT NULL,
+      event TEXT NOT NULL,
+      url TEXT NOT NULL,
+      status_code INT NOT NULL,
+      error TEXT NOT NULL,
+      duration_ms BIGINT NOT NULL,
+      create_time TIMESTAMP NOT NULL,
+      PRIMARY KEY (delivery_id, attempt)
+    );
 EOSQL
 
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "quiz_scores" <<-EOSQL
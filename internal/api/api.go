@@ -2,67 +2,181 @@ package api
 
 import (
 	"context"
+	"sync"
 
+	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 
 	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/auth"
 	"github.com/victornm/equiz/internal/domain"
 	"github.com/victornm/equiz/internal/errors"
 	"github.com/victornm/equiz/internal/event"
 	"github.com/victornm/equiz/internal/leaderboard"
+	"github.com/victornm/equiz/internal/ratelimit"
 	"github.com/victornm/equiz/internal/score"
 	"github.com/victornm/equiz/internal/session"
 )
 
 type Config struct {
 	GRPC         *grpc.Server
+	HTTP         gin.IRouter
+	Auth         *auth.Authenticator
+	RateLimit    *ratelimit.Limiter
 	EventBus     *event.Bus
-	Session      *session.Service
+	Session      Sessions
 	Score        *score.Service
 	Leaderboard  *leaderboard.Service
 	Redis        Redis
 	PubsubPrefix string
+	// Fanout is the fan-out mode of the notifications, defaults to FanoutUser.
+	Fanout FanoutMode
+	// Retention of the notifications, the zero fields are defaulted to 1000 notifications for 24 hours.
+	Retention Retention
+	// LeaderboardUpdates is how the leaderboard updates are published, full leaderboards by default.
+	LeaderboardUpdates LeaderboardUpdates
+}
+
+// Sessions are the quiz sessions, it is implemented by *session.Service.
+type Sessions interface {
+	CreateSession(ctx context.Context, req session.CreateSessionRequest) (*domain.Session, error)
+	GetSession(ctx context.Context, req session.GetSessionRequest) (*domain.Session, error)
+	JoinSession(ctx context.Context, req session.JoinSessionRequest) (*domain.Session, error)
+	StartSession(ctx context.Context, req session.StartSessionRequest) (*domain.Session, error)
+	EndSession(ctx context.Context, req session.EndSessionRequest) (*domain.Session, error)
+	StartQuestion(ctx context.Context, req session.StartQuestionRequest) error
+	EndQuestion(ctx context.Context, req session.EndQuestionRequest) error
+	ValidateSubmission(ctx context.Context, req session.ValidateSubmissionRequest) (*session.ValidateSubmissionResponse, error)
 }
 
 type Redis interface {
+	redis.Scripter
+	Get(ctx context.Context, key string) *redis.StringCmd
+	HMGet(ctx context.Context, key string, fields ...string) *redis.SliceCmd
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
 	Publish(ctx context.Context, channel string, message any) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	XRange(ctx context.Context, stream, start, stop string) *redis.XMessageSliceCmd
+	XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
 }
 
 type API struct {
 	equizv1.UnimplementedQuizServiceServer
 
-	qss *session.Service
+	qss Sessions
 	ss  *score.Service
 	ls  *leaderboard.Service
 
-	redis  Redis
-	prefix string
+	redis     Redis
+	prefix    string
+	limiter   *ratelimit.Limiter
+	fanout    FanoutMode
+	retention Retention
+
+	leaderboardUpdates LeaderboardUpdates
+
+	// ws are the connected WebSocket clients, they are closed by CloseWebSockets.
+	ws struct {
+		sync.Mutex
+		clients  map[*wsClient]struct{}
+		handlers sync.WaitGroup
+		closed   bool
+	}
 }
 
 func New(c Config) *API {
 	a := &API{
-		qss:    c.Session,
-		ss:     c.Score,
-		ls:     c.Leaderboard,
-		redis:  c.Redis,
-		prefix: c.PubsubPrefix,
+		qss:       c.Session,
+		ss:        c.Score,
+		ls:        c.Leaderboard,
+		redis:     c.Redis,
+		prefix:    c.PubsubPrefix,
+		limiter:   c.RateLimit,
+		fanout:    c.Fanout,
+		retention: c.Retention,
+
+		leaderboardUpdates: c.LeaderboardUpdates,
+	}
+	a.ws.clients = make(map[*wsClient]struct{})
+
+	if a.fanout == "" {
+		a.fanout = FanoutUser
+	}
+
+	if a.retention.MaxLen <= 0 {
+		a.retention.MaxLen = defaultMaxNotifications
+	}
+
+	if a.retention.TTL <= 0 {
+		a.retention.TTL = defaultNotificationsTTL
+	}
+
+	if a.leaderboardUpdates.SnapshotInterval <= 0 {
+		a.leaderboardUpdates.SnapshotInterval = defaultSnapshotInterval
+	}
+
+	if a.leaderboardUpdates.TopN <= 0 {
+		a.leaderboardUpdates.TopN = defaultTopN
 	}
 
 	// gRPC APIs
 	equizv1.RegisterQuizServiceServer(c.GRPC, a)
 
+	// HTTP APIs
+	authenticated := c.HTTP.Group("", auth.Middleware(c.Auth))
+	a.registerREST(authenticated)
+
+	// The WebSocket and Server-Sent Events clients can give the token in the query.
+	streaming := c.HTTP.Group("", auth.Middleware(c.Auth, auth.WithQueryToken()))
+	streaming.GET("/ws", a.serveWebSocket)
+	streaming.GET("/sessions/:id/leaderboard/stream", a.streamLeaderboard)
+	streaming.GET("/sessions/:id/watch", ratelimit.Middleware(a.limiter, "WatchSession"), a.watchSession)
+
 	// Register event handlers
-	c.EventBus.Subscribe(domain.EventNameLeaderboardUpdated, func(ctx context.Context, e event.Event) error {
-		return a.PublishLeaderboardUpdated(ctx, e.(domain.EventLeaderboardUpdated))
-	})
+	// Notifications of a session are partitioned by the session, so they are published in order.
+	event.Subscribe(c.EventBus, a.PublishLeaderboardUpdated,
+		event.PartitionBy(func(e domain.EventLeaderboardUpdated) string {
+			return e.Leaderboard.SessionID
+		}),
+	)
+	event.Subscribe(c.EventBus, a.PublishSessionStarted,
+		event.PartitionBy(func(e domain.EventSessionStarted) string {
+			return e.Session.SessionID
+		}),
+	)
+	event.Subscribe(c.EventBus, a.PublishSessionEnded,
+		event.PartitionBy(func(e domain.EventSessionEnded) string {
+			return e.Session.SessionID
+		}),
+	)
+	event.Subscribe(c.EventBus, a.PublishQuestionStarted,
+		event.PartitionBy(func(e domain.EventQuestionStarted) string {
+			return e.SessionID
+		}),
+	)
+	event.Subscribe(c.EventBus, a.PublishQuestionEnded,
+		event.PartitionBy(func(e domain.EventQuestionEnded) string {
+			return e.SessionID
+		}),
+	)
 
 	return a
 }
 
 func (a *API) CreateSession(ctx context.Context, req *equizv1.CreateSessionRequest) (*equizv1.CreateSessionResponse, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeSelf(p, req.QuizMaster); err != nil { //nolint:staticcheck // The deprecated field is checked for compatibility.
+		return nil, err
+	}
+
 	ss, err := a.qss.CreateSession(ctx, session.CreateSessionRequest{
-		QuizMaster:  req.QuizMaster,
+		QuizMaster:  p.Username,
 		QuestionIDs: req.QuestionIds,
 	})
 	if err != nil {
@@ -80,10 +194,100 @@ func (a *API) CreateSession(ctx context.Context, req *equizv1.CreateSessionReque
 	return resp, nil
 }
 
+func (a *API) JoinSession(ctx context.Context, req *equizv1.JoinSessionRequest) (*equizv1.JoinSessionResponse, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := a.qss.JoinSession(ctx, session.JoinSessionRequest{
+		SessionID: req.SessionId,
+		Username:  p.Username,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &equizv1.JoinSessionResponse{
+		Session: &equizv1.Session{
+			SessionId:   ss.SessionID,
+			QuizMaster:  ss.QuizMaster,
+			QuestionIds: ss.QuestionIDs,
+		},
+	}, nil
+}
+
+func (a *API) StartSession(ctx context.Context, req *equizv1.StartSessionRequest) (*equizv1.StartSessionResponse, error) {
+	if _, err := a.authorizeQuizMaster(ctx, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.qss.StartSession(ctx, session.StartSessionRequest{SessionID: req.SessionId}); err != nil {
+		return nil, err
+	}
+
+	return &equizv1.StartSessionResponse{}, nil
+}
+
+func (a *API) EndSession(ctx context.Context, req *equizv1.EndSessionRequest) (*equizv1.EndSessionResponse, error) {
+	if _, err := a.authorizeQuizMaster(ctx, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.qss.EndSession(ctx, session.EndSessionRequest{SessionID: req.SessionId}); err != nil {
+		return nil, err
+	}
+
+	return &equizv1.EndSessionResponse{}, nil
+}
+
+func (a *API) StartQuestion(ctx context.Context, req *equizv1.StartQuestionRequest) (*equizv1.StartQuestionResponse, error) {
+	if _, err := a.authorizeQuizMaster(ctx, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	if err := a.qss.StartQuestion(ctx, session.StartQuestionRequest{
+		SessionID:  req.SessionId,
+		QuestionID: req.QuestionId,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &equizv1.StartQuestionResponse{}, nil
+}
+
+func (a *API) EndQuestion(ctx context.Context, req *equizv1.EndQuestionRequest) (*equizv1.EndQuestionResponse, error) {
+	if _, err := a.authorizeQuizMaster(ctx, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	if err := a.qss.EndQuestion(ctx, session.EndQuestionRequest{
+		SessionID:  req.SessionId,
+		QuestionID: req.QuestionId,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &equizv1.EndQuestionResponse{}, nil
+}
+
 func (a *API) SubmitAnswer(ctx context.Context, req *equizv1.SubmitAnswerRequest) (*equizv1.SubmitAnswerResponse, error) {
-	_, err := a.qss.ValidateSubmission(ctx, session.ValidateSubmissionRequest{
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeSelf(p, req.Username); err != nil { //nolint:staticcheck // The deprecated field is checked for compatibility.
+		return nil, err
+	}
+
+	if _, err := a.authorizeMember(ctx, p, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	_, err = a.qss.ValidateSubmission(ctx, session.ValidateSubmissionRequest{
 		SessionID:  req.SessionId,
-		Username:   req.Username,
+		Username:   p.Username,
 		QuestionID: req.QuestionId,
 		SubmitTime: req.SubmitTime.AsTime(),
 	})
@@ -93,21 +297,21 @@ func (a *API) SubmitAnswer(ctx context.Context, req *equizv1.SubmitAnswerRequest
 
 	sc, err := a.ss.SubmitAnswer(ctx, score.SubmitAnswerRequest{
 		SessionID:  req.SessionId,
-		Username:   req.Username,
+		Username:   p.Username,
 		QuestionID: req.QuestionId,
 		Answer:     req.Answer,
 		SubmitTime: req.SubmitTime.AsTime(),
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	if e := errors.Convert(err); e.Code == errors.CodeAlreadyExists {
+	if e := errors.Convert(err); err != nil && e.Code == errors.CodeAlreadyExists {
 		return nil, errors.New(errors.CodeAlreadyExists,
-			errors.WithMessagef("answer is already submitted: session=%s username=%s, question=%s", req.SessionId, req.Username, req.QuestionId),
+			errors.WithMessagef("answer is already submitted: session=%s username=%s, question=%s", req.SessionId, p.Username, req.QuestionId),
+			errors.WithReason(errors.ReasonAnswerAlreadySubmitted, "session_id", req.SessionId, "question_id", req.QuestionId),
 			errors.WithCause(e.Unwrap()),
 		)
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	return &equizv1.SubmitAnswerResponse{
 		Score:      sc.Score.InexactFloat64(),
@@ -116,26 +320,34 @@ func (a *API) SubmitAnswer(ctx context.Context, req *equizv1.SubmitAnswerRequest
 }
 
 func (a *API) GetLeaderboard(ctx context.Context, req *equizv1.GetLeaderboardRequest) (*equizv1.GetLeaderboardResponse, error) {
-	l, err := a.ls.GetLeaderboard(ctx, leaderboard.GetLeaderboardRequest{
-		SessionID: req.SessionId,
-	})
+	p, err := principal(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := &equizv1.GetLeaderboardResponse{
-		Leaderboard: &equizv1.Leaderboard{
-			SessionId: l.SessionID,
-			Entries:   make([]*equizv1.LeaderboardEntry, 0, len(l.Entries)),
-		},
+	if _, err := a.authorizeMember(ctx, p, req.SessionId); err != nil {
+		return nil, err
 	}
 
-	for _, e := range l.Entries {
-		resp.Leaderboard.Entries = append(resp.Leaderboard.Entries, &equizv1.LeaderboardEntry{
-			Username: e.Username,
-			Score:    e.Score,
-		})
+	l, err := a.currentLeaderboard(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
 	}
 
-	return resp, nil
+	pl, err := toProtoLeaderboard(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return &equizv1.GetLeaderboardResponse{Leaderboard: pl}, nil
+}
+
+// principal returns the authenticated caller, the usernames are derived from it rather than the requests.
+func principal(ctx context.Context) (auth.Principal, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return auth.Principal{}, errors.New(errors.CodeUnauthenticated, errors.WithMessagef("authentication is required"))
+	}
+
+	return p, nil
 }
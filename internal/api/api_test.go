@@ -0,0 +1,245 @@
+package api_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/victornm/equiz/internal/api"
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/event"
+	"github.com/victornm/equiz/internal/leaderboard"
+	"github.com/victornm/equiz/internal/ratelimit"
+	"github.com/victornm/equiz/internal/session"
+)
+
+const (
+	prefix = "test"
+	secret = "secret"
+
+	// s1 is the session of the quiz master qm and the participants u1 and u2.
+	s1 = "s1"
+)
+
+type testAPI struct {
+	*api.API
+	redis       *redis.Client
+	bus         *event.Bus
+	http        *gin.Engine
+	leaderboard *leaderboard.Service
+}
+
+// newTestAPI returns an API backed by miniredis, the zero fields of the config are the test dependencies.
+func newTestAPI(t *testing.T, c api.Config) testAPI {
+	t.Helper()
+
+	r := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	b := event.NewBus()
+	e := gin.New()
+
+	t.Cleanup(func() {
+		require.NoError(t, b.Stop(context.Background()))
+	})
+
+	authenticator, err := auth.NewAuthenticator(auth.Config{Secret: secret})
+	require.NoError(t, err)
+
+	c.GRPC = grpc.NewServer()
+	c.HTTP = e
+	c.Auth = authenticator
+	c.EventBus = b
+	c.Redis = r
+	c.PubsubPrefix = prefix
+
+	if c.Session == nil {
+		c.Session = &fakeSessions{sessions: map[string]domain.Session{
+			s1: {SessionID: s1, QuizMaster: "qm", Participants: []string{"u1", "u2"}},
+		}}
+	}
+
+	if c.RateLimit == nil {
+		c.RateLimit = ratelimit.NewLimiter(ratelimit.Config{Redis: r, Prefix: prefix})
+	}
+
+	if c.Leaderboard == nil {
+		c.Leaderboard = leaderboard.NewService(leaderboard.Config{EventBus: b, Redis: r, Prefix: prefix})
+	}
+
+	return testAPI{API: api.New(c), redis: r, bus: b, http: e, leaderboard: c.Leaderboard}
+}
+
+// subscribe subscribes to the channels, and waits for the subscription to be confirmed.
+func (a testAPI) subscribe(t *testing.T, channels ...string) <-chan *redis.Message {
+	t.Helper()
+
+	sub := a.redis.Subscribe(context.Background(), channels...)
+	t.Cleanup(func() { _ = sub.Close() })
+
+	for range channels {
+		_, err := sub.Receive(context.Background())
+		require.NoError(t, err)
+	}
+
+	return sub.Channel()
+}
+
+// updateScores updates the leaderboard of the session with the total scores of the users.
+func (a testAPI) updateScores(t *testing.T, session string, scores map[string]float64) {
+	t.Helper()
+
+	for u, s := range scores {
+		require.NoError(t, a.redis.ZAdd(context.Background(), prefix+":{"+session+"}:leaderboard", redis.Z{Score: s, Member: u}).Err())
+	}
+}
+
+// userContext returns a context authenticated as the user.
+func userContext(user string, roles ...string) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Username: user, Roles: roles})
+}
+
+// token returns a bearer token of the user.
+func token(t *testing.T, user string) string {
+	t.Helper()
+
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   user,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return s
+}
+
+// watchStream is a WatchSession stream sending the responses to a channel.
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header chan struct{}
+	sent   chan *equizv1.WatchSessionResponse
+}
+
+func newWatchStream(ctx context.Context) *watchStream {
+	return &watchStream{
+		ctx:    ctx,
+		header: make(chan struct{}),
+		sent:   make(chan *equizv1.WatchSessionResponse, 100),
+	}
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) SendHeader(metadata.MD) error {
+	close(s.header)
+	return nil
+}
+
+func (s *watchStream) Send(resp *equizv1.WatchSessionResponse) error {
+	s.sent <- resp
+	return nil
+}
+
+// watch starts WatchSession, and waits for it to subscribe.
+// It returns the stream, and the channel of the error WatchSession returns with.
+func (a testAPI) watch(t *testing.T, ctx context.Context, req *equizv1.WatchSessionRequest) (*watchStream, <-chan error) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream := newWatchStream(ctx)
+	errc, done := make(chan error, 1), make(chan struct{})
+
+	go func() {
+		defer close(done)
+		errc <- a.WatchSession(req, stream)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	select {
+	case <-stream.header:
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watch not started")
+	}
+
+	return stream, errc
+}
+
+// next returns the next response of the stream.
+func (s *watchStream) next(t *testing.T) *equizv1.WatchSessionResponse {
+	t.Helper()
+
+	select {
+	case resp := <-s.sent:
+		return resp
+	case <-time.After(2 * time.Second):
+		t.Fatal("response not sent")
+		return nil
+	}
+}
+
+// fakeSessions are the quiz sessions in memory.
+type fakeSessions struct {
+	mu       sync.Mutex
+	sessions map[string]domain.Session
+}
+
+func (f *fakeSessions) CreateSession(context.Context, session.CreateSessionRequest) (*domain.Session, error) {
+	return nil, errors.New(errors.CodeUnimplemented)
+}
+
+func (f *fakeSessions) GetSession(_ context.Context, req session.GetSessionRequest) (*domain.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ss, ok := f.sessions[req.SessionID]
+	if !ok {
+		return nil, errors.New(errors.CodeNotFound, errors.WithReason(errors.ReasonSessionNotFound, "session_id", req.SessionID))
+	}
+	ss.Participants = append([]string(nil), ss.Participants...)
+
+	return &ss, nil
+}
+
+func (f *fakeSessions) JoinSession(ctx context.Context, req session.JoinSessionRequest) (*domain.Session, error) {
+	if _, err := f.GetSession(ctx, session.GetSessionRequest{SessionID: req.SessionID}); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	ss := f.sessions[req.SessionID]
+	ss.Participants = append(ss.Participants, req.Username)
+	f.sessions[req.SessionID] = ss
+	f.mu.Unlock()
+
+	return f.GetSession(ctx, session.GetSessionRequest{SessionID: req.SessionID})
+}
+
+func (f *fakeSessions) StartSession(ctx context.Context, req session.StartSessionRequest) (*domain.Session, error) {
+	return f.GetSession(ctx, session.GetSessionRequest{SessionID: req.SessionID})
+}
+
+func (f *fakeSessions) EndSession(ctx context.Context, req session.EndSessionRequest) (*domain.Session, error) {
+	return f.GetSession(ctx, session.GetSessionRequest{SessionID: req.SessionID})
+}
+
+func (f *fakeSessions) StartQuestion(context.Context, session.StartQuestionRequest) error { return nil }
+
+func (f *fakeSessions) EndQuestion(context.Context, session.EndQuestionRequest) error { return nil }
+
+func (f *fakeSessions) ValidateSubmission(context.Context, session.ValidateSubmissionRequest) (*session.ValidateSubmissionResponse, error) {
+	return &session.ValidateSubmissionResponse{}, nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"slices"
+
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/session"
+)
+
+// authorizeQuizMaster returns the session if the caller is its quiz master or an admin.
+func (a *API) authorizeQuizMaster(ctx context.Context, sessionID string) (*domain.Session, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := a.qss.GetSession(ctx, session.GetSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Username != ss.QuizMaster && !p.HasRole(auth.RoleAdmin) {
+		return nil, errors.New(errors.CodePermissionDenied,
+			errors.WithMessagef("only the quiz master can manage the session: session=%s", sessionID),
+			errors.WithReason(errors.ReasonNotQuizMaster, "session_id", sessionID),
+		)
+	}
+
+	return ss, nil
+}
+
+// authorizeMember returns the session if the user is one of its participants, its quiz master or an admin.
+func (a *API) authorizeMember(ctx context.Context, p auth.Principal, sessionID string) (*domain.Session, error) {
+	ss, err := a.qss.GetSession(ctx, session.GetSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Username != ss.QuizMaster && !slices.Contains(ss.Participants, p.Username) && !p.HasRole(auth.RoleAdmin) {
+		return nil, errors.New(errors.CodePermissionDenied,
+			errors.WithMessagef("only the participants can follow the session: session=%s username=%s", sessionID, p.Username),
+			errors.WithReason(errors.ReasonNotParticipant, "session_id", sessionID),
+		)
+	}
+
+	return ss, nil
+}
+
+// authorizeSelf checks the username given in a request is the caller, or empty.
+func authorizeSelf(p auth.Principal, username string) error {
+	if username != "" && username != p.Username {
+		return errors.New(errors.CodePermissionDenied,
+			errors.WithMessagef("user %s can't act for user %s", p.Username, username),
+			errors.WithReason(errors.ReasonUserMismatch, "username", username),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/leaderboard"
+)
+
+const (
+	// NotificationLeaderboardDelta is the event of the notifications with the changes of the leaderboard.
+	NotificationLeaderboardDelta = "leaderboard.delta"
+
+	// defaultSnapshotInterval is the default number of versions between the full leaderboard notifications.
+	defaultSnapshotInterval = 100
+
+	// maxVersionConflicts is the maximum number of retries when the leaderboard is published concurrently.
+	maxVersionConflicts = 3
+)
+
+// LeaderboardUpdates is how the leaderboard updates are published.
+type LeaderboardUpdates struct {
+	// Delta publishes only the changed entries of the leaderboard, with a version number.
+	Delta bool
+	// SnapshotInterval is the number of versions between the full leaderboard notifications, defaults to 100.
+	SnapshotInterval int64
+	// Personalized publishes a leaderboard personalized for each participant, with FanoutUser.
+	Personalized bool
+	// TopN is the number of top entries in the personalized leaderboards, defaults to 10.
+	TopN int
+}
+
+// LeaderboardDelta is the changes of the leaderboard from the previous version.
+type LeaderboardDelta struct {
+	SessionID string `json:"session_id"`
+	Version   int64  `json:"version"`
+	// Entries are the new entries, and the entries whose score or rank changed.
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// publishLeaderboardScript publishes like publishLua and stores the leaderboard, if the version is still the previous one.
+// KEYS[3]: published leaderboard key. ARGV[5]: previous version, ARGV[6]: leaderboard JSON.
+var publishLeaderboardScript = redis.NewScript(`
+local version = tonumber(redis.call('HGET', KEYS[3], 'version') or '0')
+if version ~= tonumber(ARGV[5]) then
+	return false
+end
+redis.call('HSET', KEYS[3], 'version', version + 1, 'data', ARGV[6])
+redis.call('EXPIRE', KEYS[3], ARGV[3])
+` + publishLua)
+
+// publishLeaderboardDelta publishes the changes from the published leaderboard, or a snapshot every SnapshotInterval.
+func (a *API) publishLeaderboardDelta(ctx context.Context, l Leaderboard) (string, error) {
+	for range maxVersionConflicts {
+		prev, err := a.publishedLeaderboard(ctx, l.SessionID)
+		if err != nil {
+			return "", err
+		}
+
+		l.Version = prev.Version + 1
+
+		var (
+			event = NotificationLeaderboardDelta
+			data  any
+		)
+
+		delta := diffLeaderboard(prev, l)
+		switch {
+		case prev.Version == 0 || l.Version%a.leaderboardUpdates.SnapshotInterval == 0:
+			event, data = domain.EventNameLeaderboardUpdated, l
+		case len(delta.Entries) == 0:
+			return "", nil
+		default:
+			data = delta
+		}
+
+		state, err := json.Marshal(l)
+		if err != nil {
+			return "", fmt.Errorf("pubsub: marshal leaderboard: %v", err)
+		}
+
+		payload, err := a.runPublishScript(ctx, publishLeaderboardScript, l.SessionID, event, data,
+			[]string{a.leaderboardKey(l.SessionID)}, prev.Version, state,
+		)
+		if stderrors.Is(err, redis.Nil) {
+			// The leaderboard is published by another instance in between, compute the delta again.
+			continue
+		}
+
+		return payload, err
+	}
+
+	return "", fmt.Errorf("pubsub: publish leaderboard delta: session=%s: too many version conflicts", l.SessionID)
+}
+
+// publishedLeaderboard returns the last published leaderboard of a session, of version 0 if none.
+func (a *API) publishedLeaderboard(ctx context.Context, session string) (Leaderboard, error) {
+	l := Leaderboard{
+		SessionID: session,
+		Entries:   []LeaderboardEntry{},
+	}
+
+	res, err := a.redis.HMGet(ctx, a.leaderboardKey(session), "version", "data").Result()
+	if err != nil {
+		return Leaderboard{}, fmt.Errorf("pubsub: get published leaderboard: session=%s: %w", session, err)
+	}
+
+	data, ok := res[1].(string)
+	if !ok {
+		return l, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &l); err != nil {
+		return Leaderboard{}, fmt.Errorf("pubsub: decode published leaderboard: session=%s: %w", session, err)
+	}
+
+	version, _ := res[0].(string)
+	if l.Version, err = strconv.ParseInt(version, 10, 64); err != nil {
+		return Leaderboard{}, fmt.Errorf("pubsub: parse published leaderboard version: session=%s: %w", session, err)
+	}
+
+	return l, nil
+}
+
+// currentLeaderboard returns the live leaderboard of a session with the version read before it.
+func (a *API) currentLeaderboard(ctx context.Context, session string) (Leaderboard, error) {
+	var version int64
+	if a.leaderboardUpdates.Delta {
+		prev, err := a.publishedLeaderboard(ctx, session)
+		if err != nil {
+			return Leaderboard{}, err
+		}
+
+		version = prev.Version
+	}
+
+	// The live leaderboard is read after the version, so no delta of a greater version is missed.
+	l, err := a.ls.GetLeaderboard(ctx, leaderboard.GetLeaderboardRequest{SessionID: session})
+	if err != nil {
+		return Leaderboard{}, err
+	}
+
+	data := toLeaderboard(*l)
+	data.Version = version
+
+	return data, nil
+}
+
+// diffLeaderboard returns the entries of the next leaderboard which are new or changed from the previous one.
+func diffLeaderboard(prev, next Leaderboard) LeaderboardDelta {
+	entries := make(map[string]LeaderboardEntry, len(prev.Entries))
+	for _, e := range prev.Entries {
+		entries[e.Username] = e
+	}
+
+	delta := LeaderboardDelta{
+		SessionID: next.SessionID,
+		Version:   next.Version,
+		Entries:   []LeaderboardEntry{},
+	}
+
+	for _, e := range next.Entries {
+		if p, ok := entries[e.Username]; !ok || p != e {
+			delta.Entries = append(delta.Entries, e)
+		}
+	}
+
+	return delta
+}
+
+func toProtoLeaderboardEntries(entries []LeaderboardEntry) ([]*equizv1.LeaderboardEntry, error) {
+	res := make([]*equizv1.LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		score, err := strconv.ParseFloat(e.Score, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse score of %s: %w", e.Username, err)
+		}
+
+		res = append(res, &equizv1.LeaderboardEntry{
+			Username: e.Username,
+			Score:    score,
+			Rank:     int32(e.Rank),
+		})
+	}
+
+	return res, nil
+}
+
+func toProtoLeaderboard(l Leaderboard) (*equizv1.Leaderboard, error) {
+	entries, err := toProtoLeaderboardEntries(l.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &equizv1.Leaderboard{
+		SessionId: l.SessionID,
+		Entries:   entries,
+		Version:   l.Version,
+	}, nil
+}
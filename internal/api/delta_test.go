@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+)
+
+func TestDiffLeaderboard(t *testing.T) {
+	tests := map[string]struct {
+		prev, next []api.LeaderboardEntry
+		want       []api.LeaderboardEntry
+	}{
+		"should include all entries of the first version": {
+			next: []api.LeaderboardEntry{{Username: "u1", Score: "1", Rank: 1}},
+			want: []api.LeaderboardEntry{{Username: "u1", Score: "1", Rank: 1}},
+		},
+		"should be empty if nothing changed": {
+			prev: []api.LeaderboardEntry{{Username: "u1", Score: "1", Rank: 1}},
+			next: []api.LeaderboardEntry{{Username: "u1", Score: "1", Rank: 1}},
+			want: []api.LeaderboardEntry{},
+		},
+		"should include the new entries": {
+			prev: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}},
+			next: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "1", Rank: 2}},
+			want: []api.LeaderboardEntry{{Username: "u2", Score: "1", Rank: 2}},
+		},
+		"should include the entries whose score changed": {
+			prev: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "1", Rank: 2}},
+			next: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "1.5", Rank: 2}},
+			want: []api.LeaderboardEntry{{Username: "u2", Score: "1.5", Rank: 2}},
+		},
+		"should include the entries whose rank changed": {
+			prev: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "1", Rank: 2}},
+			next: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}, {Username: "u1", Score: "2", Rank: 2}},
+			want: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}, {Username: "u1", Score: "2", Rank: 2}},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			delta := api.DiffLeaderboard(
+				api.Leaderboard{SessionID: s1, Entries: tt.prev, Version: 1},
+				api.Leaderboard{SessionID: s1, Entries: tt.next, Version: 2},
+			)
+			assert.Equal(t, api.LeaderboardDelta{SessionID: s1, Version: 2, Entries: tt.want}, delta)
+		})
+	}
+}
+
+func TestAPI_PublishLeaderboardScript(t *testing.T) {
+	tests := map[string]struct {
+		published    []int64
+		prev         int64
+		wantConflict bool
+		wantVersion  string
+		wantSequence int64
+	}{
+		"should publish the first version": {
+			prev:         0,
+			wantVersion:  "1",
+			wantSequence: 1,
+		},
+		"should publish the next version": {
+			published:    []int64{0},
+			prev:         1,
+			wantVersion:  "2",
+			wantSequence: 2,
+		},
+		"should not publish if another version is published in between": {
+			published:    []int64{0, 1},
+			prev:         1,
+			wantConflict: true,
+			wantVersion:  "2",
+			wantSequence: 2,
+		},
+		"should not publish if the previous version is not published": {
+			prev:         1,
+			wantConflict: true,
+			wantSequence: 0,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{LeaderboardUpdates: api.LeaderboardUpdates{Delta: true}})
+			ctx := context.Background()
+
+			l := api.Leaderboard{SessionID: s1, Entries: []api.LeaderboardEntry{{Username: "u1", Score: "1", Rank: 1}}}
+			for _, prev := range tt.published {
+				_, err := a.PublishLeaderboardVersion(ctx, l, prev)
+				require.NoError(t, err)
+			}
+
+			l.Entries[0].Score = "2"
+			payload, err := a.PublishLeaderboardVersion(ctx, l, tt.prev)
+			if tt.wantConflict {
+				require.True(t, stderrors.Is(err, redis.Nil), "unexpected error: %v", err)
+			} else {
+				require.NoError(t, err)
+
+				var n api.Notification
+				require.NoError(t, json.Unmarshal([]byte(payload), &n))
+				assert.Equal(t, tt.wantSequence, n.Sequence)
+			}
+
+			key := prefix + ":session:{s1}:leaderboard"
+			version, err := a.redis.HGet(ctx, key, "version").Result()
+			if tt.wantVersion == "" {
+				require.ErrorIs(t, err, redis.Nil)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, version)
+
+				ttl, err := a.redis.TTL(ctx, key).Result()
+				require.NoError(t, err)
+				assert.Greater(t, ttl, time.Duration(0))
+			}
+
+			n, err := a.redis.XLen(ctx, prefix+":session:{s1}:notifications").Result()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSequence, n, "the notification should only be appended if published")
+		})
+	}
+}
+
+func TestAPI_GetLeaderboard_Delta(t *testing.T) {
+	a := newTestAPI(t, api.Config{LeaderboardUpdates: api.LeaderboardUpdates{Delta: true}})
+	ctx := context.Background()
+
+	a.updateScores(t, s1, map[string]float64{"u1": 2})
+	require.NoError(t, a.PublishLeaderboardUpdated(ctx, domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+		SessionID: s1,
+		Entries:   []domain.LeaderboardEntry{{Username: "u1", Score: 2}},
+	}}))
+
+	// The score of u2 is not published yet.
+	a.updateScores(t, s1, map[string]float64{"u2": 3})
+
+	resp, err := a.GetLeaderboard(userContext("u1"), &equizv1.GetLeaderboardRequest{SessionId: s1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Leaderboard.Version, "the version should be of the published leaderboard")
+
+	usernames := make([]string, 0, len(resp.Leaderboard.Entries))
+	for _, e := range resp.Leaderboard.Entries {
+		usernames = append(usernames, e.Username)
+	}
+	assert.Equal(t, []string{"u2", "u1"}, usernames, "the entries should be of the live leaderboard")
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/victornm/equiz/internal/domain"
+)
+
+// DiffLeaderboard exports diffLeaderboard to the tests.
+var DiffLeaderboard = diffLeaderboard
+
+// PublishLeaderboardVersion publishes the full leaderboard with publishLeaderboardScript,
+// if the version of the published leaderboard is prev.
+func (a *API) PublishLeaderboardVersion(ctx context.Context, l Leaderboard, prev int64) (string, error) {
+	state, err := json.Marshal(l)
+	if err != nil {
+		return "", err
+	}
+
+	return a.runPublishScript(ctx, publishLeaderboardScript, l.SessionID, domain.EventNameLeaderboardUpdated, l,
+		[]string{a.leaderboardKey(l.SessionID)}, prev, state,
+	)
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// renderError writes the public error as the JSON body, with the HTTP status code of the error.
+func renderError(c *gin.Context, err error) {
+	e := errors.Public(c.Request.Context(), err, "method", c.Request.Method, "path", c.FullPath())
+	c.AbortWithStatusJSON(e.HTTPStatusCode(), e)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// maxListNotifications is the maximum number of notifications returned by ListNotifications.
+const maxListNotifications = 100
+
+// ListNotifications returns the retained notifications of a session after a sequence to its members.
+func (a *API) ListNotifications(ctx context.Context, req *equizv1.ListNotificationsRequest) (*equizv1.ListNotificationsResponse, error) {
+	p, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SessionId == "" {
+		return nil, errors.InvalidField("session_id", "is required")
+	}
+
+	if req.AfterSequence < 0 {
+		return nil, errors.InvalidField("after_sequence", "must not be negative")
+	}
+
+	if req.PageSize < 0 {
+		return nil, errors.InvalidField("page_size", "must not be negative")
+	}
+
+	if _, err := a.authorizeMember(ctx, p, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	size := int64(req.PageSize)
+	if size == 0 || size > maxListNotifications {
+		size = maxListNotifications
+	}
+
+	// The sequence is read before the notifications, so the ones published after it don't make the response truncated.
+	seq, err := a.currentSequence(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read one more notification to know if there are more.
+	ns, err := a.readNotifications(ctx, req.SessionId, req.AfterSequence, size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &equizv1.ListNotificationsResponse{
+		Notifications: make([]*equizv1.WatchSessionResponse, 0, min(int64(len(ns)), size)),
+	}
+
+	if int64(len(ns)) > size {
+		ns = ns[:size]
+		resp.HasMore = true
+	}
+
+	// The notifications are truncated if the next one is not retained.
+	if len(ns) > 0 {
+		resp.Truncated = ns[0].Sequence > req.AfterSequence+1
+	} else {
+		resp.Truncated = seq > req.AfterSequence
+	}
+
+	for _, n := range ns {
+		r, ok, err := toWatchSessionResponse(n)
+		if err != nil {
+			slog.ErrorContext(ctx, "api: convert notification failed",
+				"session", req.SessionId,
+				"sequence", n.Sequence,
+				"error", err,
+			)
+			continue
+		}
+
+		if ok {
+			resp.Notifications = append(resp.Notifications, r)
+		}
+	}
+
+	return resp, nil
+}
+
+// readNotifications returns the retained notifications after a sequence, at most count if positive.
+func (a *API) readNotifications(ctx context.Context, session string, after, count int64) ([]rawNotification, error) {
+	var (
+		key   = a.notificationsKey(session)
+		start = fmt.Sprintf("%d-0", after+1)
+		msgs  []redis.XMessage
+		err   error
+	)
+
+	if count > 0 {
+		msgs, err = a.redis.XRangeN(ctx, key, start, "+", count).Result()
+	} else {
+		msgs, err = a.redis.XRange(ctx, key, start, "+").Result()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pubsub: read notifications: session=%s: %w", session, err)
+	}
+
+	ns := make([]rawNotification, 0, len(msgs))
+	for _, msg := range msgs {
+		payload, _ := msg.Values["data"].(string)
+		n, err := decodeNotification(payload)
+		if err != nil {
+			slog.ErrorContext(ctx, "api: decode notification failed", "session", session, "id", msg.ID, "error", err)
+			continue
+		}
+		ns = append(ns, n)
+	}
+
+	return ns, nil
+}
+
+// currentSequence returns the sequence of the last notification of a session, 0 if there is none.
+func (a *API) currentSequence(ctx context.Context, session string) (int64, error) {
+	seq, err := a.redis.Get(ctx, a.sequenceKey(session)).Int64()
+	if err != nil && err != redis.Nil {
+		return 0, fmt.Errorf("pubsub: get sequence: session=%s: %w", session, err)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestAPI_ListNotifications(t *testing.T) {
+	tests := map[string]struct {
+		ctx           context.Context
+		req           *equizv1.ListNotificationsRequest
+		trim          bool
+		wantSequences []int64
+		wantTruncated bool
+		wantHasMore   bool
+		wantCode      errors.Code
+	}{
+		"should list the notifications after the sequence": {
+			ctx:           userContext("u1"),
+			req:           &equizv1.ListNotificationsRequest{SessionId: s1, AfterSequence: 1},
+			wantSequences: []int64{2, 3},
+		},
+		"should list a page of the notifications": {
+			ctx:           userContext("qm"),
+			req:           &equizv1.ListNotificationsRequest{SessionId: s1, PageSize: 2},
+			wantSequences: []int64{1, 2},
+			wantHasMore:   true,
+		},
+		"should be truncated if the next notification is no longer retained": {
+			ctx:           userContext("admin", "admin"),
+			req:           &equizv1.ListNotificationsRequest{SessionId: s1, AfterSequence: 1},
+			trim:          true,
+			wantSequences: []int64{3},
+			wantTruncated: true,
+		},
+		"should be empty after the last notification": {
+			ctx:           userContext("u1"),
+			req:           &equizv1.ListNotificationsRequest{SessionId: s1, AfterSequence: 3},
+			wantSequences: []int64{},
+		},
+		"should deny the users not in the session": {
+			ctx:      userContext("u3"),
+			req:      &equizv1.ListNotificationsRequest{SessionId: s1},
+			wantCode: errors.CodePermissionDenied,
+		},
+		"should reject negative sequences": {
+			ctx:      userContext("u1"),
+			req:      &equizv1.ListNotificationsRequest{SessionId: s1, AfterSequence: -1},
+			wantCode: errors.CodeInvalidArgument,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{})
+			ctx := context.Background()
+
+			for _, q := range []string{"q1", "q2", "q3"} {
+				require.NoError(t, a.PublishQuestionStarted(ctx, domain.EventQuestionStarted{SessionID: s1, QuestionID: q}))
+			}
+
+			if tt.trim {
+				require.NoError(t, a.redis.XDel(ctx, prefix+":session:{s1}:notifications", "2-0").Err())
+			}
+
+			resp, err := a.ListNotifications(tt.ctx, tt.req)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errors.Convert(err).Code)
+				return
+			}
+			require.NoError(t, err)
+
+			sequences := make([]int64, 0, len(resp.Notifications))
+			for _, n := range resp.Notifications {
+				sequences = append(sequences, n.Sequence)
+			}
+			assert.Equal(t, tt.wantSequences, sequences)
+			assert.Equal(t, tt.wantTruncated, resp.Truncated)
+			assert.Equal(t, tt.wantHasMore, resp.HasMore)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/session"
+)
+
+const (
+	// NotificationLeaderboardPersonalized is the event of the leaderboard updates personalized for each participant.
+	NotificationLeaderboardPersonalized = "leaderboard.personalized"
+
+	// defaultTopN is the default number of top entries in the personalized leaderboard updates.
+	defaultTopN = 10
+)
+
+// PersonalizedLeaderboard is the leaderboard update of a participant, with its position and the top entries.
+type PersonalizedLeaderboard struct {
+	SessionID string `json:"session_id"`
+	Username  string `json:"username"`
+	// Rank is 0 for the participants without a score.
+	Rank  int    `json:"rank"`
+	Score string `json:"score"`
+	// RankChange is the number of places moved up since the last update, negative if moved down.
+	RankChange int `json:"rank_change"`
+	// Gap is the score needed to reach the next position, 0 for the first position.
+	Gap   string             `json:"gap"`
+	Top   []LeaderboardEntry `json:"top"`
+	Total int                `json:"total"`
+}
+
+// userMessage is a message published to the channel of a user.
+type userMessage struct {
+	user    string
+	payload string
+}
+
+// publishPersonalizedLeaderboards publishes a personalized leaderboard to each participant with the same sequence.
+func (a *API) publishPersonalizedLeaderboards(ctx context.Context, l domain.Leaderboard, payload string) error {
+	n, err := decodeNotification(payload)
+	if err != nil {
+		return fmt.Errorf("pubsub: decode published leaderboard: %w", err)
+	}
+
+	ss, err := a.qss.GetSession(ctx, session.GetSessionRequest{SessionID: l.SessionID})
+	if err != nil {
+		return fmt.Errorf("pubsub: get session: session=%s: %w", l.SessionID, err)
+	}
+
+	prev, err := a.redis.HGetAll(ctx, a.ranksKey(l.SessionID)).Result()
+	if err != nil {
+		return fmt.Errorf("pubsub: get ranks: session=%s: %w", l.SessionID, err)
+	}
+
+	data := toLeaderboard(l)
+	top := data.Entries[:min(len(data.Entries), a.leaderboardUpdates.TopN)]
+
+	users := make([]string, 0, len(data.Entries)+len(ss.Participants))
+	personalized := make(map[string]PersonalizedLeaderboard, len(data.Entries)+len(ss.Participants))
+	ranks := make(map[string]any, len(data.Entries))
+	for i, e := range data.Entries {
+		p := PersonalizedLeaderboard{
+			SessionID: l.SessionID,
+			Username:  e.Username,
+			Rank:      e.Rank,
+			Score:     e.Score,
+			Gap:       "0",
+			Top:       top,
+			Total:     len(data.Entries),
+		}
+
+		if r, ok := prev[e.Username]; ok {
+			if rank, err := strconv.Atoi(r); err == nil {
+				p.RankChange = rank - e.Rank
+			}
+		}
+
+		if i > 0 {
+			// Subtract as decimals, so the gap isn't affected by the floating point errors.
+			p.Gap = decimal.NewFromFloat(l.Entries[i-1].Score).Sub(decimal.NewFromFloat(l.Entries[i].Score)).String()
+		}
+
+		users = append(users, e.Username)
+		personalized[e.Username] = p
+		ranks[e.Username] = e.Rank
+	}
+
+	// The participants without a score also receive one, so their watchers don't wait for it.
+	for _, u := range ss.Participants {
+		if _, ok := personalized[u]; ok {
+			continue
+		}
+
+		p := PersonalizedLeaderboard{
+			SessionID: l.SessionID,
+			Username:  u,
+			Score:     "0",
+			Gap:       "0",
+			Top:       top,
+			Total:     len(data.Entries),
+		}
+		if len(l.Entries) > 0 {
+			p.Gap = decimal.NewFromFloat(l.Entries[len(l.Entries)-1].Score).String()
+		}
+
+		users = append(users, u)
+		personalized[u] = p
+	}
+
+	messages := make([]userMessage, 0, len(users))
+	for _, u := range users {
+		b, err := json.Marshal(Notification{
+			Sequence:  n.Sequence,
+			SessionID: l.SessionID,
+			Event:     NotificationLeaderboardPersonalized,
+			Data:      personalized[u],
+
+			NotificationMetadata: n.NotificationMetadata,
+		})
+		if err != nil {
+			return fmt.Errorf("pubsub: marshal personalized leaderboard: %v", err)
+		}
+
+		messages = append(messages, userMessage{user: u, payload: string(b)})
+	}
+
+	if err := a.publishToUsers(ctx, messages); err != nil {
+		return err
+	}
+
+	if len(ranks) == 0 {
+		return nil
+	}
+
+	if _, err := a.redis.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, a.ranksKey(l.SessionID), ranks)
+		p.Expire(ctx, a.ranksKey(l.SessionID), a.retention.TTL)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("pubsub: store ranks: session=%s: %w", l.SessionID, err)
+	}
+
+	return nil
+}
+
+func toProtoPersonalizedLeaderboard(p PersonalizedLeaderboard) (*equizv1.PersonalizedLeaderboard, error) {
+	score, err := strconv.ParseFloat(p.Score, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse score of %s: %w", p.Username, err)
+	}
+
+	gap, err := strconv.ParseFloat(p.Gap, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse gap of %s: %w", p.Username, err)
+	}
+
+	top, err := toProtoLeaderboardEntries(p.Top)
+	if err != nil {
+		return nil, err
+	}
+
+	return &equizv1.PersonalizedLeaderboard{
+		SessionId:  p.SessionID,
+		Username:   p.Username,
+		Rank:       int32(p.Rank),
+		Score:      score,
+		RankChange: int32(p.RankChange),
+		Gap:        gap,
+		Top:        top,
+		Total:      int32(p.Total),
+	}, nil
+}
@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/domain"
+)
+
+func TestAPI_PublishLeaderboardUpdated_Personalized(t *testing.T) {
+	tests := map[string]struct {
+		topN        int
+		updates     [][]domain.LeaderboardEntry
+		want        map[string]api.PersonalizedLeaderboard
+		wantSession []api.LeaderboardEntry
+	}{
+		"should send the first entry without a gap and the top entries": {
+			topN:    1,
+			updates: [][]domain.LeaderboardEntry{{{Username: "u1", Score: 3}, {Username: "u2", Score: 1.5}}},
+			want: map[string]api.PersonalizedLeaderboard{
+				"u1": {Username: "u1", Rank: 1, Score: "3", Gap: "0", Top: []api.LeaderboardEntry{{Username: "u1", Score: "3", Rank: 1}}, Total: 2},
+				"u2": {Username: "u2", Rank: 2, Score: "1.5", Gap: "1.5", Top: []api.LeaderboardEntry{{Username: "u1", Score: "3", Rank: 1}}, Total: 2},
+				"u3": {Username: "u3", Rank: 0, Score: "0", Gap: "1.5", Top: []api.LeaderboardEntry{{Username: "u1", Score: "3", Rank: 1}}, Total: 2},
+			},
+			wantSession: []api.LeaderboardEntry{{Username: "u1", Score: "3", Rank: 1}},
+		},
+		"should send no gap for the ties": {
+			topN:    2,
+			updates: [][]domain.LeaderboardEntry{{{Username: "u1", Score: 2}, {Username: "u2", Score: 2}}},
+			want: map[string]api.PersonalizedLeaderboard{
+				"u1": {Username: "u1", Rank: 1, Score: "2", Gap: "0", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "2", Rank: 2}}, Total: 2},
+				"u2": {Username: "u2", Rank: 2, Score: "2", Gap: "0", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "2", Rank: 2}}, Total: 2},
+				"u3": {Username: "u3", Rank: 0, Score: "0", Gap: "2", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "2", Rank: 2}}, Total: 2},
+			},
+			wantSession: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}, {Username: "u2", Score: "2", Rank: 2}},
+		},
+		"should send the rank changes since the last update": {
+			topN: 1,
+			updates: [][]domain.LeaderboardEntry{
+				{{Username: "u1", Score: 2}, {Username: "u2", Score: 1}},
+				{{Username: "u2", Score: 3}, {Username: "u1", Score: 2}, {Username: "u3", Score: 0.1}},
+			},
+			want: map[string]api.PersonalizedLeaderboard{
+				"u1": {Username: "u1", Rank: 2, Score: "2", RankChange: -1, Gap: "1", Top: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}}, Total: 3},
+				"u2": {Username: "u2", Rank: 1, Score: "3", RankChange: 1, Gap: "0", Top: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}}, Total: 3},
+				"u3": {Username: "u3", Rank: 3, Score: "0.1", Gap: "1.9", Top: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}}, Total: 3},
+			},
+			wantSession: []api.LeaderboardEntry{{Username: "u2", Score: "3", Rank: 1}},
+		},
+		"should send all the entries if there are fewer than the top entries": {
+			topN:    10,
+			updates: [][]domain.LeaderboardEntry{{{Username: "u1", Score: 2}}},
+			want: map[string]api.PersonalizedLeaderboard{
+				"u1": {Username: "u1", Rank: 1, Score: "2", Gap: "0", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}}, Total: 1},
+				"u2": {Username: "u2", Rank: 0, Score: "0", Gap: "2", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}}, Total: 1},
+				"u3": {Username: "u3", Rank: 0, Score: "0", Gap: "2", Top: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}}, Total: 1},
+			},
+			wantSession: []api.LeaderboardEntry{{Username: "u1", Score: "2", Rank: 1}},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{
+				Fanout:             api.FanoutUser,
+				LeaderboardUpdates: api.LeaderboardUpdates{Personalized: true, TopN: tt.topN},
+				Session: &fakeSessions{sessions: map[string]domain.Session{
+					s1: {SessionID: s1, QuizMaster: "qm", Participants: []string{"u1", "u2", "u3"}},
+				}},
+			})
+			ch := a.subscribe(t, prefix+":session:s1", prefix+":user:u1", prefix+":user:u2", prefix+":user:u3")
+
+			for _, entries := range tt.updates {
+				require.NoError(t, a.PublishLeaderboardUpdated(context.Background(), domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+					SessionID: s1,
+					Entries:   entries,
+				}}))
+			}
+
+			// The messages of each channel are received in order, only the last ones are checked.
+			last := map[string]string{}
+			for i := 0; i < len(tt.updates)*(len(tt.want)+1); i++ {
+				select {
+				case msg := <-ch:
+					last[msg.Channel] = msg.Payload
+				case <-time.After(time.Second):
+					t.Fatalf("notification not published, received on %v", last)
+				}
+			}
+
+			var session struct {
+				api.Notification
+				Data api.Leaderboard `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(last[prefix+":session:s1"]), &session))
+			require.Equal(t, tt.wantSession, session.Data.Entries, "the session channel should only have the top entries")
+
+			for user, want := range tt.want {
+				var n struct {
+					api.Notification
+					Data api.PersonalizedLeaderboard `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(last[prefix+":user:"+user]), &n))
+
+				want.SessionID = s1
+				require.Equal(t, session.Sequence, n.Sequence, "the personalized leaderboard should have the sequence of the session one")
+				require.Equal(t, api.NotificationLeaderboardPersonalized, n.Event)
+				require.Equal(t, want, n.Data, user)
+			}
+		})
+	}
+}
@@ -21,6 +21,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type QuestionStatus int32
+
+const (
+	QuestionStatus_QUESTION_STATUS_UNSPECIFIED QuestionStatus = 0
+	QuestionStatus_QUESTION_STATUS_STARTED     QuestionStatus = 1
+	QuestionStatus_QUESTION_STATUS_ENDED       QuestionStatus = 2
+)
+
+// Enum value maps for QuestionStatus.
+var (
+	QuestionStatus_name = map[int32]string{
+		0: "QUESTION_STATUS_UNSPECIFIED",
+		1: "QUESTION_STATUS_STARTED",
+		2: "QUESTION_STATUS_ENDED",
+	}
+	QuestionStatus_value = map[string]int32{
+		"QUESTION_STATUS_UNSPECIFIED": 0,
+		"QUESTION_STATUS_STARTED":     1,
+		"QUESTION_STATUS_ENDED":       2,
+	}
+)
+
+func (x QuestionStatus) Enum() *QuestionStatus {
+	p := new(QuestionStatus)
+	*p = x
+	return p
+}
+
+func (x QuestionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuestionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_equiz_v1_equiz_proto_enumTypes[0].Descriptor()
+}
+
+func (QuestionStatus) Type() protoreflect.EnumType {
+	return &file_equiz_v1_equiz_proto_enumTypes[0]
+}
+
+func (x QuestionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuestionStatus.Descriptor instead.
+func (QuestionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{0}
+}
+
+type SessionStatus int32
+
+const (
+	SessionStatus_SESSION_STATUS_UNSPECIFIED SessionStatus = 0
+	SessionStatus_SESSION_STATUS_STARTED     SessionStatus = 1
+	SessionStatus_SESSION_STATUS_ENDED       SessionStatus = 2
+)
+
+// Enum value maps for SessionStatus.
+var (
+	SessionStatus_name = map[int32]string{
+		0: "SESSION_STATUS_UNSPECIFIED",
+		1: "SESSION_STATUS_STARTED",
+		2: "SESSION_STATUS_ENDED",
+	}
+	SessionStatus_value = map[string]int32{
+		"SESSION_STATUS_UNSPECIFIED": 0,
+		"SESSION_STATUS_STARTED":     1,
+		"SESSION_STATUS_ENDED":       2,
+	}
+)
+
+func (x SessionStatus) Enum() *SessionStatus {
+	p := new(SessionStatus)
+	*p = x
+	return p
+}
+
+func (x SessionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_equiz_v1_equiz_proto_enumTypes[1].Descriptor()
+}
+
+func (SessionStatus) Type() protoreflect.EnumType {
+	return &file_equiz_v1_equiz_proto_enumTypes[1]
+}
+
+func (x SessionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionStatus.Descriptor instead.
+func (SessionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{1}
+}
+
 type Session struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,8 +308,10 @@ type CreateSessionRequest struct {
 	// request_id is a unique identifier for the request, it is used for idempotency.
 	// validation: optional
 	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	// quiz_master is the username of the user creating the quiz session
-	// validation: required
+	// quiz_master is the username of the user creating the quiz session.
+	// Deprecated: the quiz master is the authenticated user, if it is set, it must be the authenticated user.
+	//
+	// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
 	QuizMaster string `protobuf:"bytes,2,opt,name=quiz_master,json=quizMaster,proto3" json:"quiz_master,omitempty"`
 	// question_ids is the list of unique identifiers for the questions in the quiz session
 	// validation: required,unique,min=1,max=100,dive,required
@@ -257,6 +357,7 @@ func (x *CreateSessionRequest) GetRequestId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
 func (x *CreateSessionRequest) GetQuizMaster() string {
 	if x != nil {
 		return x.QuizMaster
@@ -318,14 +419,19 @@ func (x *CreateSessionResponse) GetSession() *Session {
 	return nil
 }
 
-type StartSessionRequest struct {
+type JoinSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// request_id is a unique identifier for the request, it is used for idempotency
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// session_id is the unique identifier for the quiz session
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *StartSessionRequest) Reset() {
-	*x = StartSessionRequest{}
+func (x *JoinSessionRequest) Reset() {
+	*x = JoinSessionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -333,13 +439,13 @@ func (x *StartSessionRequest) Reset() {
 	}
 }
 
-func (x *StartSessionRequest) String() string {
+func (x *JoinSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSessionRequest) ProtoMessage() {}
+func (*JoinSessionRequest) ProtoMessage() {}
 
-func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *JoinSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -351,19 +457,35 @@ func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSessionRequest.ProtoReflect.Descriptor instead.
-func (*StartSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinSessionRequest.ProtoReflect.Descriptor instead.
+func (*JoinSessionRequest) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{5}
 }
 
-type StartSessionResponse struct {
+func (x *JoinSessionRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *JoinSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type JoinSessionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Session *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
 }
 
-func (x *StartSessionResponse) Reset() {
-	*x = StartSessionResponse{}
+func (x *JoinSessionResponse) Reset() {
+	*x = JoinSessionResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -371,13 +493,13 @@ func (x *StartSessionResponse) Reset() {
 	}
 }
 
-func (x *StartSessionResponse) String() string {
+func (x *JoinSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSessionResponse) ProtoMessage() {}
+func (*JoinSessionResponse) ProtoMessage() {}
 
-func (x *StartSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *JoinSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -389,12 +511,19 @@ func (x *StartSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSessionResponse.ProtoReflect.Descriptor instead.
-func (*StartSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinSessionResponse.ProtoReflect.Descriptor instead.
+func (*JoinSessionResponse) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{6}
 }
 
-type EndSessionRequest struct {
+func (x *JoinSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type StartSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -405,8 +534,8 @@ type EndSessionRequest struct {
 	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *EndSessionRequest) Reset() {
-	*x = EndSessionRequest{}
+func (x *StartSessionRequest) Reset() {
+	*x = StartSessionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -414,13 +543,13 @@ func (x *EndSessionRequest) Reset() {
 	}
 }
 
-func (x *EndSessionRequest) String() string {
+func (x *StartSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndSessionRequest) ProtoMessage() {}
+func (*StartSessionRequest) ProtoMessage() {}
 
-func (x *EndSessionRequest) ProtoReflect() protoreflect.Message {
+func (x *StartSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -432,33 +561,33 @@ func (x *EndSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndSessionRequest.ProtoReflect.Descriptor instead.
-func (*EndSessionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartSessionRequest) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *EndSessionRequest) GetRequestId() string {
+func (x *StartSessionRequest) GetRequestId() string {
 	if x != nil {
 		return x.RequestId
 	}
 	return ""
 }
 
-func (x *EndSessionRequest) GetSessionId() string {
+func (x *StartSessionRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-type EndSessionResponse struct {
+type StartSessionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *EndSessionResponse) Reset() {
-	*x = EndSessionResponse{}
+func (x *StartSessionResponse) Reset() {
+	*x = StartSessionResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -466,13 +595,13 @@ func (x *EndSessionResponse) Reset() {
 	}
 }
 
-func (x *EndSessionResponse) String() string {
+func (x *StartSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndSessionResponse) ProtoMessage() {}
+func (*StartSessionResponse) ProtoMessage() {}
 
-func (x *EndSessionResponse) ProtoReflect() protoreflect.Message {
+func (x *StartSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -484,19 +613,24 @@ func (x *EndSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndSessionResponse.ProtoReflect.Descriptor instead.
-func (*EndSessionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartSessionResponse.ProtoReflect.Descriptor instead.
+func (*StartSessionResponse) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{8}
 }
 
-type GetCurrentQuestionRequest struct {
+type EndSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// request_id is a unique identifier for the request, it is used for idempotency
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// session_id is the unique identifier for the quiz session
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *GetCurrentQuestionRequest) Reset() {
-	*x = GetCurrentQuestionRequest{}
+func (x *EndSessionRequest) Reset() {
+	*x = EndSessionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -504,13 +638,13 @@ func (x *GetCurrentQuestionRequest) Reset() {
 	}
 }
 
-func (x *GetCurrentQuestionRequest) String() string {
+func (x *EndSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentQuestionRequest) ProtoMessage() {}
+func (*EndSessionRequest) ProtoMessage() {}
 
-func (x *GetCurrentQuestionRequest) ProtoReflect() protoreflect.Message {
+func (x *EndSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -522,19 +656,33 @@ func (x *GetCurrentQuestionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentQuestionRequest.ProtoReflect.Descriptor instead.
-func (*GetCurrentQuestionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndSessionRequest.ProtoReflect.Descriptor instead.
+func (*EndSessionRequest) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{9}
 }
 
-type GetCurrentQuestionResponse struct {
+func (x *EndSessionRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *EndSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type EndSessionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GetCurrentQuestionResponse) Reset() {
-	*x = GetCurrentQuestionResponse{}
+func (x *EndSessionResponse) Reset() {
+	*x = EndSessionResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -542,13 +690,13 @@ func (x *GetCurrentQuestionResponse) Reset() {
 	}
 }
 
-func (x *GetCurrentQuestionResponse) String() string {
+func (x *EndSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentQuestionResponse) ProtoMessage() {}
+func (*EndSessionResponse) ProtoMessage() {}
 
-func (x *GetCurrentQuestionResponse) ProtoReflect() protoreflect.Message {
+func (x *EndSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -560,12 +708,12 @@ func (x *GetCurrentQuestionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentQuestionResponse.ProtoReflect.Descriptor instead.
-func (*GetCurrentQuestionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndSessionResponse.ProtoReflect.Descriptor instead.
+func (*EndSessionResponse) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{10}
 }
 
-type SubmitAnswerRequest struct {
+type StartQuestionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -574,18 +722,12 @@ type SubmitAnswerRequest struct {
 	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	// session_id is the unique identifier for the quiz session
 	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	// username is the username of the user submitting the answer
-	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
 	// question_id is the unique identifier for the question within the quiz session
-	QuestionId string `protobuf:"bytes,4,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
-	// answer is the answer submitted by the user
-	Answer string `protobuf:"bytes,5,opt,name=answer,proto3" json:"answer,omitempty"`
-	// submit_time is the time at which the answer was submitted
-	SubmitTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=submit_time,json=submitTime,proto3" json:"submit_time,omitempty"`
+	QuestionId string `protobuf:"bytes,3,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
 }
 
-func (x *SubmitAnswerRequest) Reset() {
-	*x = SubmitAnswerRequest{}
+func (x *StartQuestionRequest) Reset() {
+	*x = StartQuestionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -593,13 +735,13 @@ func (x *SubmitAnswerRequest) Reset() {
 	}
 }
 
-func (x *SubmitAnswerRequest) String() string {
+func (x *StartQuestionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitAnswerRequest) ProtoMessage() {}
+func (*StartQuestionRequest) ProtoMessage() {}
 
-func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *StartQuestionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -611,66 +753,40 @@ func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitAnswerRequest.ProtoReflect.Descriptor instead.
-func (*SubmitAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartQuestionRequest.ProtoReflect.Descriptor instead.
+func (*StartQuestionRequest) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SubmitAnswerRequest) GetRequestId() string {
+func (x *StartQuestionRequest) GetRequestId() string {
 	if x != nil {
 		return x.RequestId
 	}
 	return ""
 }
 
-func (x *SubmitAnswerRequest) GetSessionId() string {
+func (x *StartQuestionRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *SubmitAnswerRequest) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
-}
-
-func (x *SubmitAnswerRequest) GetQuestionId() string {
+func (x *StartQuestionRequest) GetQuestionId() string {
 	if x != nil {
 		return x.QuestionId
 	}
 	return ""
 }
 
-func (x *SubmitAnswerRequest) GetAnswer() string {
-	if x != nil {
-		return x.Answer
-	}
-	return ""
-}
-
-func (x *SubmitAnswerRequest) GetSubmitTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.SubmitTime
-	}
-	return nil
-}
-
-type SubmitAnswerResponse struct {
+type StartQuestionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// score is the score awarded to the user for the answer submitted
-	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
-	// total_score is the total score of the user in the quiz session
-	TotalScore float64 `protobuf:"fixed64,2,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
 }
 
-func (x *SubmitAnswerResponse) Reset() {
-	*x = SubmitAnswerResponse{}
+func (x *StartQuestionResponse) Reset() {
+	*x = StartQuestionResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -678,13 +794,13 @@ func (x *SubmitAnswerResponse) Reset() {
 	}
 }
 
-func (x *SubmitAnswerResponse) String() string {
+func (x *StartQuestionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitAnswerResponse) ProtoMessage() {}
+func (*StartQuestionResponse) ProtoMessage() {}
 
-func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
+func (x *StartQuestionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -696,35 +812,26 @@ func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitAnswerResponse.ProtoReflect.Descriptor instead.
-func (*SubmitAnswerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartQuestionResponse.ProtoReflect.Descriptor instead.
+func (*StartQuestionResponse) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SubmitAnswerResponse) GetScore() float64 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *SubmitAnswerResponse) GetTotalScore() float64 {
-	if x != nil {
-		return x.TotalScore
-	}
-	return 0
-}
-
-type GetLeaderboardRequest struct {
+type EndQuestionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// request_id is a unique identifier for the request, it is used for idempotency
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// session_id is the unique identifier for the quiz session
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// question_id is the unique identifier for the question within the quiz session
+	QuestionId string `protobuf:"bytes,3,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
 }
 
-func (x *GetLeaderboardRequest) Reset() {
-	*x = GetLeaderboardRequest{}
+func (x *EndQuestionRequest) Reset() {
+	*x = EndQuestionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_equiz_v1_equiz_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -732,13 +839,13 @@ func (x *GetLeaderboardRequest) Reset() {
 	}
 }
 
-func (x *GetLeaderboardRequest) String() string {
+func (x *EndQuestionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLeaderboardRequest) ProtoMessage() {}
+func (*EndQuestionRequest) ProtoMessage() {}
 
-func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+func (x *EndQuestionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_equiz_v1_equiz_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -750,43 +857,1019 @@ func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
-func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EndQuestionRequest.ProtoReflect.Descriptor instead.
+func (*EndQuestionRequest) Descriptor() ([]byte, []int) {
 	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetLeaderboardRequest) GetSessionId() string {
+func (x *EndQuestionRequest) GetRequestId() string {
 	if x != nil {
-		return x.SessionId
+		return x.RequestId
 	}
 	return ""
 }
 
-type GetLeaderboardResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *EndQuestionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *EndQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+type EndQuestionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EndQuestionResponse) Reset() {
+	*x = EndQuestionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EndQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndQuestionResponse) ProtoMessage() {}
+
+func (x *EndQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndQuestionResponse.ProtoReflect.Descriptor instead.
+func (*EndQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{14}
+}
+
+type GetCurrentQuestionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCurrentQuestionRequest) Reset() {
+	*x = GetCurrentQuestionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCurrentQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentQuestionRequest) ProtoMessage() {}
+
+func (x *GetCurrentQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentQuestionRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{15}
+}
+
+type GetCurrentQuestionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCurrentQuestionResponse) Reset() {
+	*x = GetCurrentQuestionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCurrentQuestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentQuestionResponse) ProtoMessage() {}
+
+func (x *GetCurrentQuestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentQuestionResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentQuestionResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{16}
+}
+
+type SubmitAnswerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// request_id is a unique identifier for the request, it is used for idempotency
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// session_id is the unique identifier for the quiz session
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// username is the username of the user submitting the answer.
+	// Deprecated: the user is the authenticated user, if it is set, it must be the authenticated user.
+	//
+	// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// question_id is the unique identifier for the question within the quiz session
+	QuestionId string `protobuf:"bytes,4,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	// answer is the answer submitted by the user
+	Answer string `protobuf:"bytes,5,opt,name=answer,proto3" json:"answer,omitempty"`
+	// submit_time is the time at which the answer was submitted
+	SubmitTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=submit_time,json=submitTime,proto3" json:"submit_time,omitempty"`
+}
+
+func (x *SubmitAnswerRequest) Reset() {
+	*x = SubmitAnswerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerRequest) ProtoMessage() {}
+
+func (x *SubmitAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubmitAnswerRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
+func (x *SubmitAnswerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *SubmitAnswerRequest) GetSubmitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmitTime
+	}
+	return nil
+}
+
+type SubmitAnswerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// score is the score awarded to the user for the answer submitted
+	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	// total_score is the total score of the user in the quiz session
+	TotalScore float64 `protobuf:"fixed64,2,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
+}
+
+func (x *SubmitAnswerResponse) Reset() {
+	*x = SubmitAnswerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAnswerResponse) ProtoMessage() {}
+
+func (x *SubmitAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAnswerResponse.ProtoReflect.Descriptor instead.
+func (*SubmitAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SubmitAnswerResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SubmitAnswerResponse) GetTotalScore() float64 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetLeaderboardRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Leaderboard *Leaderboard `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetLeaderboardResponse) GetLeaderboard() *Leaderboard {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+type Leaderboard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string              `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Entries   []*LeaderboardEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// version is the version of the published leaderboard, it is only set with the delta leaderboard updates.
+	// The leaderboard_delta notifications of greater versions are applied to it, the others are ignored.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Leaderboard) Reset() {
+	*x = Leaderboard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Leaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leaderboard) ProtoMessage() {}
+
+func (x *Leaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leaderboard.ProtoReflect.Descriptor instead.
+func (*Leaderboard) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Leaderboard) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Leaderboard) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Leaderboard) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string  `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Score    float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// rank is the 1-based position of the entry in the leaderboard
+	Rank int32 `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// LeaderboardDelta is the changes of the leaderboard from the previous version.
+// If the client doesn't have the previous version, it should get the full leaderboard with GetLeaderboard.
+type LeaderboardDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// version is the version of the leaderboard after applying the delta to the version - 1.
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// entries are the new entries, and the entries whose score or rank changed.
+	Entries []*LeaderboardEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *LeaderboardDelta) Reset() {
+	*x = LeaderboardDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardDelta) ProtoMessage() {}
+
+func (x *LeaderboardDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardDelta.ProtoReflect.Descriptor instead.
+func (*LeaderboardDelta) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LeaderboardDelta) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LeaderboardDelta) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LeaderboardDelta) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// PersonalizedLeaderboard is the leaderboard update of a participant, with its position and the top entries.
+type PersonalizedLeaderboard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Username  string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// rank is the 1-based position of the participant in the leaderboard, 0 without a score
+	Rank  int32   `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	// rank_change is the number of places the participant moved up since the last update, negative if moved down
+	RankChange int32 `protobuf:"varint,5,opt,name=rank_change,json=rankChange,proto3" json:"rank_change,omitempty"`
+	// gap is the score needed to reach the next position, 0 for the first position
+	Gap float64 `protobuf:"fixed64,6,opt,name=gap,proto3" json:"gap,omitempty"`
+	// top is the top entries of the leaderboard
+	Top []*LeaderboardEntry `protobuf:"bytes,7,rep,name=top,proto3" json:"top,omitempty"`
+	// total is the number of participants in the leaderboard
+	Total int32 `protobuf:"varint,8,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *PersonalizedLeaderboard) Reset() {
+	*x = PersonalizedLeaderboard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PersonalizedLeaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalizedLeaderboard) ProtoMessage() {}
+
+func (x *PersonalizedLeaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalizedLeaderboard.ProtoReflect.Descriptor instead.
+func (*PersonalizedLeaderboard) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PersonalizedLeaderboard) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PersonalizedLeaderboard) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PersonalizedLeaderboard) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PersonalizedLeaderboard) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PersonalizedLeaderboard) GetRankChange() int32 {
+	if x != nil {
+		return x.RankChange
+	}
+	return 0
+}
+
+func (x *PersonalizedLeaderboard) GetGap() float64 {
+	if x != nil {
+		return x.Gap
+	}
+	return 0
+}
+
+func (x *PersonalizedLeaderboard) GetTop() []*LeaderboardEntry {
+	if x != nil {
+		return x.Top
+	}
+	return nil
+}
+
+func (x *PersonalizedLeaderboard) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type WatchSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// session_id is the unique identifier for the quiz session
+	// validation: required
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// username is the username of the participant watching the session.
+	// Deprecated: the participant is the authenticated user, if it is set, it must be the authenticated user.
+	//
+	// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// from_sequence is the sequence of the last notification received before reconnecting,
+	// the retained notifications after it are replayed before streaming new ones.
+	// If it is 0, only new notifications are streamed.
+	FromSequence int64 `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+}
+
+func (x *WatchSessionRequest) Reset() {
+	*x = WatchSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSessionRequest) ProtoMessage() {}
+
+func (x *WatchSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSessionRequest.ProtoReflect.Descriptor instead.
+func (*WatchSessionRequest) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in equiz/v1/equiz.proto.
+func (x *WatchSessionRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WatchSessionRequest) GetFromSequence() int64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+type WatchSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sequence is the sequence of the notification within the quiz session, it is monotonically increasing
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// event is the name of the event which triggers the notification, e.g. leaderboard.updated
+	Event string `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	// Types that are assignable to Data:
+	//	*WatchSessionResponse_Leaderboard
+	//	*WatchSessionResponse_Question
+	//	*WatchSessionResponse_Session
+	//	*WatchSessionResponse_LeaderboardDelta
+	//	*WatchSessionResponse_PersonalizedLeaderboard
+	Data isWatchSessionResponse_Data `protobuf_oneof:"data"`
+	// event_id is the unique identifier of the event which triggers the notification
+	EventId string `protobuf:"bytes,8,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// trace_id is the trace of the request which publishes the event, if any
+	TraceId string `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// correlation_id is shared by the notifications caused by the same request, e.g. its X-Request-Id
+	CorrelationId string `protobuf:"bytes,10,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+}
+
+func (x *WatchSessionResponse) Reset() {
+	*x = WatchSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSessionResponse) ProtoMessage() {}
+
+func (x *WatchSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSessionResponse.ProtoReflect.Descriptor instead.
+func (*WatchSessionResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchSessionResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *WatchSessionResponse) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (m *WatchSessionResponse) GetData() isWatchSessionResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetLeaderboard() *Leaderboard {
+	if x, ok := x.GetData().(*WatchSessionResponse_Leaderboard); ok {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetQuestion() *QuestionUpdate {
+	if x, ok := x.GetData().(*WatchSessionResponse_Question); ok {
+		return x.Question
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetSession() *SessionUpdate {
+	if x, ok := x.GetData().(*WatchSessionResponse_Session); ok {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetLeaderboardDelta() *LeaderboardDelta {
+	if x, ok := x.GetData().(*WatchSessionResponse_LeaderboardDelta); ok {
+		return x.LeaderboardDelta
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetPersonalizedLeaderboard() *PersonalizedLeaderboard {
+	if x, ok := x.GetData().(*WatchSessionResponse_PersonalizedLeaderboard); ok {
+		return x.PersonalizedLeaderboard
+	}
+	return nil
+}
+
+func (x *WatchSessionResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WatchSessionResponse) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *WatchSessionResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+type isWatchSessionResponse_Data interface {
+	isWatchSessionResponse_Data()
+}
+
+type WatchSessionResponse_Leaderboard struct {
+	Leaderboard *Leaderboard `protobuf:"bytes,3,opt,name=leaderboard,proto3,oneof"`
+}
+
+type WatchSessionResponse_Question struct {
+	Question *QuestionUpdate `protobuf:"bytes,4,opt,name=question,proto3,oneof"`
+}
+
+type WatchSessionResponse_Session struct {
+	Session *SessionUpdate `protobuf:"bytes,5,opt,name=session,proto3,oneof"`
+}
+
+type WatchSessionResponse_LeaderboardDelta struct {
+	LeaderboardDelta *LeaderboardDelta `protobuf:"bytes,6,opt,name=leaderboard_delta,json=leaderboardDelta,proto3,oneof"`
+}
+
+type WatchSessionResponse_PersonalizedLeaderboard struct {
+	PersonalizedLeaderboard *PersonalizedLeaderboard `protobuf:"bytes,7,opt,name=personalized_leaderboard,json=personalizedLeaderboard,proto3,oneof"`
+}
+
+func (*WatchSessionResponse_Leaderboard) isWatchSessionResponse_Data() {}
+
+func (*WatchSessionResponse_Question) isWatchSessionResponse_Data() {}
+
+func (*WatchSessionResponse_Session) isWatchSessionResponse_Data() {}
+
+func (*WatchSessionResponse_LeaderboardDelta) isWatchSessionResponse_Data() {}
+
+func (*WatchSessionResponse_PersonalizedLeaderboard) isWatchSessionResponse_Data() {}
+
+type QuestionUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestionId string         `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Status     QuestionStatus `protobuf:"varint,2,opt,name=status,proto3,enum=equiz.v1.QuestionStatus" json:"status,omitempty"`
+}
+
+func (x *QuestionUpdate) Reset() {
+	*x = QuestionUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_equiz_v1_equiz_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuestionUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionUpdate) ProtoMessage() {}
+
+func (x *QuestionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionUpdate.ProtoReflect.Descriptor instead.
+func (*QuestionUpdate) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QuestionUpdate) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *QuestionUpdate) GetStatus() QuestionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return QuestionStatus_QUESTION_STATUS_UNSPECIFIED
+}
+
+type SessionUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Leaderboard *Leaderboard `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Status SessionStatus `protobuf:"varint,1,opt,name=status,proto3,enum=equiz.v1.SessionStatus" json:"status,omitempty"`
 }
 
-func (x *GetLeaderboardResponse) Reset() {
-	*x = GetLeaderboardResponse{}
+func (x *SessionUpdate) Reset() {
+	*x = SessionUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_equiz_v1_equiz_proto_msgTypes[14]
+		mi := &file_equiz_v1_equiz_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetLeaderboardResponse) String() string {
+func (x *SessionUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLeaderboardResponse) ProtoMessage() {}
+func (*SessionUpdate) ProtoMessage() {}
 
-func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_equiz_v1_equiz_proto_msgTypes[14]
+func (x *SessionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,44 +1880,50 @@ func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
-func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
-	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SessionUpdate.ProtoReflect.Descriptor instead.
+func (*SessionUpdate) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *GetLeaderboardResponse) GetLeaderboard() *Leaderboard {
+func (x *SessionUpdate) GetStatus() SessionStatus {
 	if x != nil {
-		return x.Leaderboard
+		return x.Status
 	}
-	return nil
+	return SessionStatus_SESSION_STATUS_UNSPECIFIED
 }
 
-type Leaderboard struct {
+type ListNotificationsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string              `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Entries   []*LeaderboardEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// session_id is the unique identifier for the quiz session
+	// validation: required
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// after_sequence is the sequence of the last notification received, the notifications after it are returned.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// page_size is the maximum number of notifications returned, at most 100.
+	// If it is 0, 100 notifications are returned at most.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 }
 
-func (x *Leaderboard) Reset() {
-	*x = Leaderboard{}
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_equiz_v1_equiz_proto_msgTypes[15]
+		mi := &file_equiz_v1_equiz_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Leaderboard) String() string {
+func (x *ListNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Leaderboard) ProtoMessage() {}
+func (*ListNotificationsRequest) ProtoMessage() {}
 
-func (x *Leaderboard) ProtoReflect() protoreflect.Message {
-	mi := &file_equiz_v1_equiz_proto_msgTypes[15]
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,51 +1934,63 @@ func (x *Leaderboard) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Leaderboard.ProtoReflect.Descriptor instead.
-func (*Leaderboard) Descriptor() ([]byte, []int) {
-	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *Leaderboard) GetSessionId() string {
+func (x *ListNotificationsRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *Leaderboard) GetEntries() []*LeaderboardEntry {
+func (x *ListNotificationsRequest) GetAfterSequence() int64 {
 	if x != nil {
-		return x.Entries
+		return x.AfterSequence
 	}
-	return nil
+	return 0
 }
 
-type LeaderboardEntry struct {
+func (x *ListNotificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListNotificationsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string  `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Score    float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// notifications are the retained notifications after after_sequence, in the order of their sequences.
+	Notifications []*WatchSessionResponse `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// truncated is true if some notifications after after_sequence are no longer retained,
+	// the client should get the current state instead, e.g. the leaderboard.
+	Truncated bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// has_more is true if there are more notifications, the client should list them after the last returned sequence.
+	HasMore bool `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
 }
 
-func (x *LeaderboardEntry) Reset() {
-	*x = LeaderboardEntry{}
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_equiz_v1_equiz_proto_msgTypes[16]
+		mi := &file_equiz_v1_equiz_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LeaderboardEntry) String() string {
+func (x *ListNotificationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaderboardEntry) ProtoMessage() {}
+func (*ListNotificationsResponse) ProtoMessage() {}
 
-func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_equiz_v1_equiz_proto_msgTypes[16]
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_equiz_v1_equiz_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,23 +2001,30 @@ func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
-func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
-	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_equiz_v1_equiz_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *LeaderboardEntry) GetUsername() string {
+func (x *ListNotificationsResponse) GetNotifications() []*WatchSessionResponse {
 	if x != nil {
-		return x.Username
+		return x.Notifications
 	}
-	return ""
+	return nil
 }
 
-func (x *LeaderboardEntry) GetScore() float64 {
+func (x *ListNotificationsResponse) GetTruncated() bool {
 	if x != nil {
-		return x.Score
+		return x.Truncated
 	}
-	return 0
+	return false
+}
+
+func (x *ListNotificationsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
 }
 
 var File_equiz_v1_equiz_proto protoreflect.FileDescriptor
@@ -945,102 +2053,267 @@ var file_equiz_v1_equiz_proto_rawDesc = []byte{
 	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69,
 	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74,
 	0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x65, 0x78, 0x74, 0x22, 0x79, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x6e, 0x54, 0x65, 0x78, 0x74, 0x22, 0x7d, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
 	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
 	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0b,
 	0x71, 0x75, 0x69, 0x7a, 0x5f, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x7a, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a,
-	0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73,
-	0x22, 0x44, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x71, 0x75,
-	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a,
-	0x14, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x51, 0x0a, 0x11, 0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x6e, 0x64, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b,
-	0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x1c, 0x0a, 0x1a, 0x47,
-	0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe5, 0x01, 0x0a, 0x13, 0x53, 0x75,
-	0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
-	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x7a, 0x4d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x73, 0x22, 0x44, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
+	0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a, 0x12, 0x4a, 0x6f,
+	0x69, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x42,
+	0x0a, 0x13, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x53, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x51, 0x0a, 0x11, 0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x75, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22,
+	0x17, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x73, 0x0a, 0x12, 0x45, 0x6e, 0x64, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x15, 0x0a,
+	0x13, 0x45, 0x6e, 0x64, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x1c, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0xe9, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3b,
+	0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4d, 0x0a, 0x14, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x36, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x51, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0x7c, 0x0a, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x22, 0x81, 0x01,
+	0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x44, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65,
+	0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0xf5, 0x01, 0x0a, 0x17, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x64, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x67, 0x61, 0x70, 0x12, 0x2c, 0x0a, 0x03, 0x74, 0x6f, 0x70, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
+	0x74, 0x6f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x79, 0x0a, 0x13, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
-	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d,
-	0x65, 0x22, 0x4d, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
-	0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x22, 0x36, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x51, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0b,
-	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x22, 0x62, 0x0a, 0x0b, 0x4c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x1e, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x22, 0x80, 0x04, 0x0a, 0x14, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x39, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x6c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65,
+	0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x11, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x48, 0x00,
+	0x52, 0x10, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x44, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x5e, 0x0a, 0x18, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x48, 0x00, 0x52, 0x17, 0x70, 0x65, 0x72, 0x73, 0x6f,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x42,
+	0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x63, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x65, 0x71, 0x75,
+	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x40, 0x0a, 0x0d,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x7d,
+	0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
 	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x71, 0x75,
-	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
-	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
-	0x44, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x32, 0xfc, 0x03, 0x0a, 0x0b, 0x51, 0x75, 0x69, 0x7a, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x45, 0x6e, 0x64, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
-	0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64,
-	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x5f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65,
-	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x65, 0x71, 0x75,
-	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x9a, 0x01,
+	0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x6e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x2a, 0x69, 0x0a, 0x0e, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x1b,
+	0x51, 0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a,
+	0x17, 0x51, 0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x4e,
+	0x44, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x65, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x32, 0x95, 0x07, 0x0a,
+	0x0b, 0x51, 0x75, 0x69, 0x7a, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0d,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e,
+	0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a,
+	0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e,
+	0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x71,
+	0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x65, 0x71, 0x75,
+	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69,
+	0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x45, 0x6e, 0x64,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x45, 0x6e, 0x64, 0x51, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x6e, 0x64, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64,
 	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x12, 0x1d, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d,
-	0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69,
-	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x53, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
-	0x64, 0x12, 0x1f, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x5f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x65, 0x71,
+	0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x12, 0x1d, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x53, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x12, 0x1f, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x65, 0x71,
+	0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x8d, 0x01, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x2e, 0x65, 0x71, 0x75,
 	0x69, 0x7a, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x45, 0x71, 0x75, 0x69, 0x7a, 0x50, 0x72, 0x6f, 0x74,
 	0x6f, 0x50, 0x01, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
@@ -1065,50 +2338,88 @@ func file_equiz_v1_equiz_proto_rawDescGZIP() []byte {
 	return file_equiz_v1_equiz_proto_rawDescData
 }
 
-var file_equiz_v1_equiz_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_equiz_v1_equiz_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_equiz_v1_equiz_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_equiz_v1_equiz_proto_goTypes = []any{
-	(*Session)(nil),                    // 0: equiz.v1.Session
-	(*Question)(nil),                   // 1: equiz.v1.Question
-	(*Option)(nil),                     // 2: equiz.v1.Option
-	(*CreateSessionRequest)(nil),       // 3: equiz.v1.CreateSessionRequest
-	(*CreateSessionResponse)(nil),      // 4: equiz.v1.CreateSessionResponse
-	(*StartSessionRequest)(nil),        // 5: equiz.v1.StartSessionRequest
-	(*StartSessionResponse)(nil),       // 6: equiz.v1.StartSessionResponse
-	(*EndSessionRequest)(nil),          // 7: equiz.v1.EndSessionRequest
-	(*EndSessionResponse)(nil),         // 8: equiz.v1.EndSessionResponse
-	(*GetCurrentQuestionRequest)(nil),  // 9: equiz.v1.GetCurrentQuestionRequest
-	(*GetCurrentQuestionResponse)(nil), // 10: equiz.v1.GetCurrentQuestionResponse
-	(*SubmitAnswerRequest)(nil),        // 11: equiz.v1.SubmitAnswerRequest
-	(*SubmitAnswerResponse)(nil),       // 12: equiz.v1.SubmitAnswerResponse
-	(*GetLeaderboardRequest)(nil),      // 13: equiz.v1.GetLeaderboardRequest
-	(*GetLeaderboardResponse)(nil),     // 14: equiz.v1.GetLeaderboardResponse
-	(*Leaderboard)(nil),                // 15: equiz.v1.Leaderboard
-	(*LeaderboardEntry)(nil),           // 16: equiz.v1.LeaderboardEntry
-	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
+	(QuestionStatus)(0),                // 0: equiz.v1.QuestionStatus
+	(SessionStatus)(0),                 // 1: equiz.v1.SessionStatus
+	(*Session)(nil),                    // 2: equiz.v1.Session
+	(*Question)(nil),                   // 3: equiz.v1.Question
+	(*Option)(nil),                     // 4: equiz.v1.Option
+	(*CreateSessionRequest)(nil),       // 5: equiz.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),      // 6: equiz.v1.CreateSessionResponse
+	(*JoinSessionRequest)(nil),         // 7: equiz.v1.JoinSessionRequest
+	(*JoinSessionResponse)(nil),        // 8: equiz.v1.JoinSessionResponse
+	(*StartSessionRequest)(nil),        // 9: equiz.v1.StartSessionRequest
+	(*StartSessionResponse)(nil),       // 10: equiz.v1.StartSessionResponse
+	(*EndSessionRequest)(nil),          // 11: equiz.v1.EndSessionRequest
+	(*EndSessionResponse)(nil),         // 12: equiz.v1.EndSessionResponse
+	(*StartQuestionRequest)(nil),       // 13: equiz.v1.StartQuestionRequest
+	(*StartQuestionResponse)(nil),      // 14: equiz.v1.StartQuestionResponse
+	(*EndQuestionRequest)(nil),         // 15: equiz.v1.EndQuestionRequest
+	(*EndQuestionResponse)(nil),        // 16: equiz.v1.EndQuestionResponse
+	(*GetCurrentQuestionRequest)(nil),  // 17: equiz.v1.GetCurrentQuestionRequest
+	(*GetCurrentQuestionResponse)(nil), // 18: equiz.v1.GetCurrentQuestionResponse
+	(*SubmitAnswerRequest)(nil),        // 19: equiz.v1.SubmitAnswerRequest
+	(*SubmitAnswerResponse)(nil),       // 20: equiz.v1.SubmitAnswerResponse
+	(*GetLeaderboardRequest)(nil),      // 21: equiz.v1.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),     // 22: equiz.v1.GetLeaderboardResponse
+	(*Leaderboard)(nil),                // 23: equiz.v1.Leaderboard
+	(*LeaderboardEntry)(nil),           // 24: equiz.v1.LeaderboardEntry
+	(*LeaderboardDelta)(nil),           // 25: equiz.v1.LeaderboardDelta
+	(*PersonalizedLeaderboard)(nil),    // 26: equiz.v1.PersonalizedLeaderboard
+	(*WatchSessionRequest)(nil),        // 27: equiz.v1.WatchSessionRequest
+	(*WatchSessionResponse)(nil),       // 28: equiz.v1.WatchSessionResponse
+	(*QuestionUpdate)(nil),             // 29: equiz.v1.QuestionUpdate
+	(*SessionUpdate)(nil),              // 30: equiz.v1.SessionUpdate
+	(*ListNotificationsRequest)(nil),   // 31: equiz.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),  // 32: equiz.v1.ListNotificationsResponse
+	(*timestamppb.Timestamp)(nil),      // 33: google.protobuf.Timestamp
 }
 var file_equiz_v1_equiz_proto_depIdxs = []int32{
-	2,  // 0: equiz.v1.Question.options:type_name -> equiz.v1.Option
-	0,  // 1: equiz.v1.CreateSessionResponse.session:type_name -> equiz.v1.Session
-	17, // 2: equiz.v1.SubmitAnswerRequest.submit_time:type_name -> google.protobuf.Timestamp
-	15, // 3: equiz.v1.GetLeaderboardResponse.leaderboard:type_name -> equiz.v1.Leaderboard
-	16, // 4: equiz.v1.Leaderboard.entries:type_name -> equiz.v1.LeaderboardEntry
-	3,  // 5: equiz.v1.QuizService.CreateSession:input_type -> equiz.v1.CreateSessionRequest
-	5,  // 6: equiz.v1.QuizService.StartSession:input_type -> equiz.v1.StartSessionRequest
-	7,  // 7: equiz.v1.QuizService.EndSession:input_type -> equiz.v1.EndSessionRequest
-	9,  // 8: equiz.v1.QuizService.GetCurrentQuestion:input_type -> equiz.v1.GetCurrentQuestionRequest
-	11, // 9: equiz.v1.QuizService.SubmitAnswer:input_type -> equiz.v1.SubmitAnswerRequest
-	13, // 10: equiz.v1.QuizService.GetLeaderboard:input_type -> equiz.v1.GetLeaderboardRequest
-	4,  // 11: equiz.v1.QuizService.CreateSession:output_type -> equiz.v1.CreateSessionResponse
-	6,  // 12: equiz.v1.QuizService.StartSession:output_type -> equiz.v1.StartSessionResponse
-	8,  // 13: equiz.v1.QuizService.EndSession:output_type -> equiz.v1.EndSessionResponse
-	10, // 14: equiz.v1.QuizService.GetCurrentQuestion:output_type -> equiz.v1.GetCurrentQuestionResponse
-	12, // 15: equiz.v1.QuizService.SubmitAnswer:output_type -> equiz.v1.SubmitAnswerResponse
-	14, // 16: equiz.v1.QuizService.GetLeaderboard:output_type -> equiz.v1.GetLeaderboardResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	4,  // 0: equiz.v1.Question.options:type_name -> equiz.v1.Option
+	2,  // 1: equiz.v1.CreateSessionResponse.session:type_name -> equiz.v1.Session
+	2,  // 2: equiz.v1.JoinSessionResponse.session:type_name -> equiz.v1.Session
+	33, // 3: equiz.v1.SubmitAnswerRequest.submit_time:type_name -> google.protobuf.Timestamp
+	23, // 4: equiz.v1.GetLeaderboardResponse.leaderboard:type_name -> equiz.v1.Leaderboard
+	24, // 5: equiz.v1.Leaderboard.entries:type_name -> equiz.v1.LeaderboardEntry
+	24, // 6: equiz.v1.LeaderboardDelta.entries:type_name -> equiz.v1.LeaderboardEntry
+	24, // 7: equiz.v1.PersonalizedLeaderboard.top:type_name -> equiz.v1.LeaderboardEntry
+	23, // 8: equiz.v1.WatchSessionResponse.leaderboard:type_name -> equiz.v1.Leaderboard
+	29, // 9: equiz.v1.WatchSessionResponse.question:type_name -> equiz.v1.QuestionUpdate
+	30, // 10: equiz.v1.WatchSessionResponse.session:type_name -> equiz.v1.SessionUpdate
+	25, // 11: equiz.v1.WatchSessionResponse.leaderboard_delta:type_name -> equiz.v1.LeaderboardDelta
+	26, // 12: equiz.v1.WatchSessionResponse.personalized_leaderboard:type_name -> equiz.v1.PersonalizedLeaderboard
+	0,  // 13: equiz.v1.QuestionUpdate.status:type_name -> equiz.v1.QuestionStatus
+	1,  // 14: equiz.v1.SessionUpdate.status:type_name -> equiz.v1.SessionStatus
+	28, // 15: equiz.v1.ListNotificationsResponse.notifications:type_name -> equiz.v1.WatchSessionResponse
+	5,  // 16: equiz.v1.QuizService.CreateSession:input_type -> equiz.v1.CreateSessionRequest
+	7,  // 17: equiz.v1.QuizService.JoinSession:input_type -> equiz.v1.JoinSessionRequest
+	9,  // 18: equiz.v1.QuizService.StartSession:input_type -> equiz.v1.StartSessionRequest
+	11, // 19: equiz.v1.QuizService.EndSession:input_type -> equiz.v1.EndSessionRequest
+	13, // 20: equiz.v1.QuizService.StartQuestion:input_type -> equiz.v1.StartQuestionRequest
+	15, // 21: equiz.v1.QuizService.EndQuestion:input_type -> equiz.v1.EndQuestionRequest
+	17, // 22: equiz.v1.QuizService.GetCurrentQuestion:input_type -> equiz.v1.GetCurrentQuestionRequest
+	19, // 23: equiz.v1.QuizService.SubmitAnswer:input_type -> equiz.v1.SubmitAnswerRequest
+	21, // 24: equiz.v1.QuizService.GetLeaderboard:input_type -> equiz.v1.GetLeaderboardRequest
+	27, // 25: equiz.v1.QuizService.WatchSession:input_type -> equiz.v1.WatchSessionRequest
+	31, // 26: equiz.v1.QuizService.ListNotifications:input_type -> equiz.v1.ListNotificationsRequest
+	6,  // 27: equiz.v1.QuizService.CreateSession:output_type -> equiz.v1.CreateSessionResponse
+	8,  // 28: equiz.v1.QuizService.JoinSession:output_type -> equiz.v1.JoinSessionResponse
+	10, // 29: equiz.v1.QuizService.StartSession:output_type -> equiz.v1.StartSessionResponse
+	12, // 30: equiz.v1.QuizService.EndSession:output_type -> equiz.v1.EndSessionResponse
+	14, // 31: equiz.v1.QuizService.StartQuestion:output_type -> equiz.v1.StartQuestionResponse
+	16, // 32: equiz.v1.QuizService.EndQuestion:output_type -> equiz.v1.EndQuestionResponse
+	18, // 33: equiz.v1.QuizService.GetCurrentQuestion:output_type -> equiz.v1.GetCurrentQuestionResponse
+	20, // 34: equiz.v1.QuizService.SubmitAnswer:output_type -> equiz.v1.SubmitAnswerResponse
+	22, // 35: equiz.v1.QuizService.GetLeaderboard:output_type -> equiz.v1.GetLeaderboardResponse
+	28, // 36: equiz.v1.QuizService.WatchSession:output_type -> equiz.v1.WatchSessionResponse
+	32, // 37: equiz.v1.QuizService.ListNotifications:output_type -> equiz.v1.ListNotificationsResponse
+	27, // [27:38] is the sub-list for method output_type
+	16, // [16:27] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_equiz_v1_equiz_proto_init() }
@@ -1178,7 +2489,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*StartSessionRequest); i {
+			switch v := v.(*JoinSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1190,7 +2501,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*StartSessionResponse); i {
+			switch v := v.(*JoinSessionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1202,7 +2513,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*EndSessionRequest); i {
+			switch v := v.(*StartSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1214,7 +2525,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*EndSessionResponse); i {
+			switch v := v.(*StartSessionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1226,7 +2537,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*GetCurrentQuestionRequest); i {
+			switch v := v.(*EndSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1238,7 +2549,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*GetCurrentQuestionResponse); i {
+			switch v := v.(*EndSessionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1250,7 +2561,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*SubmitAnswerRequest); i {
+			switch v := v.(*StartQuestionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1262,7 +2573,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*SubmitAnswerResponse); i {
+			switch v := v.(*StartQuestionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1274,7 +2585,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*GetLeaderboardRequest); i {
+			switch v := v.(*EndQuestionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1286,7 +2597,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*GetLeaderboardResponse); i {
+			switch v := v.(*EndQuestionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1298,7 +2609,7 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*Leaderboard); i {
+			switch v := v.(*GetCurrentQuestionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1310,6 +2621,78 @@ func file_equiz_v1_equiz_proto_init() {
 			}
 		}
 		file_equiz_v1_equiz_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCurrentQuestionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLeaderboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLeaderboardResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Leaderboard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[22].Exporter = func(v any, i int) any {
 			switch v := v.(*LeaderboardEntry); i {
 			case 0:
 				return &v.state
@@ -1321,19 +2704,123 @@ func file_equiz_v1_equiz_proto_init() {
 				return nil
 			}
 		}
+		file_equiz_v1_equiz_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*LeaderboardDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*PersonalizedLeaderboard); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*QuestionUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*SessionUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*ListNotificationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_equiz_v1_equiz_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ListNotificationsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_equiz_v1_equiz_proto_msgTypes[26].OneofWrappers = []any{
+		(*WatchSessionResponse_Leaderboard)(nil),
+		(*WatchSessionResponse_Question)(nil),
+		(*WatchSessionResponse_Session)(nil),
+		(*WatchSessionResponse_LeaderboardDelta)(nil),
+		(*WatchSessionResponse_PersonalizedLeaderboard)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_equiz_v1_equiz_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_equiz_v1_equiz_proto_goTypes,
 		DependencyIndexes: file_equiz_v1_equiz_proto_depIdxs,
+		EnumInfos:         file_equiz_v1_equiz_proto_enumTypes,
 		MessageInfos:      file_equiz_v1_equiz_proto_msgTypes,
 	}.Build()
 	File_equiz_v1_equiz_proto = out.File
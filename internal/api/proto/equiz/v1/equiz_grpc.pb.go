@@ -20,11 +20,16 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	QuizService_CreateSession_FullMethodName      = "/equiz.v1.QuizService/CreateSession"
+	QuizService_JoinSession_FullMethodName        = "/equiz.v1.QuizService/JoinSession"
 	QuizService_StartSession_FullMethodName       = "/equiz.v1.QuizService/StartSession"
 	QuizService_EndSession_FullMethodName         = "/equiz.v1.QuizService/EndSession"
+	QuizService_StartQuestion_FullMethodName      = "/equiz.v1.QuizService/StartQuestion"
+	QuizService_EndQuestion_FullMethodName        = "/equiz.v1.QuizService/EndQuestion"
 	QuizService_GetCurrentQuestion_FullMethodName = "/equiz.v1.QuizService/GetCurrentQuestion"
 	QuizService_SubmitAnswer_FullMethodName       = "/equiz.v1.QuizService/SubmitAnswer"
 	QuizService_GetLeaderboard_FullMethodName     = "/equiz.v1.QuizService/GetLeaderboard"
+	QuizService_WatchSession_FullMethodName       = "/equiz.v1.QuizService/WatchSession"
+	QuizService_ListNotifications_FullMethodName  = "/equiz.v1.QuizService/ListNotifications"
 )
 
 // QuizServiceClient is the client API for QuizService service.
@@ -34,11 +39,30 @@ type QuizServiceClient interface {
 	// CreateSession a new session, this API is expected to be called by the quiz master.
 	// If this API returns a successful response, the session is created with all the required questions.
 	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error)
+	// JoinSession adds the caller to the participants of a session, joining a joined session has no effect.
+	// Only the participants, the quiz master and the admins can watch the session and list its notifications.
+	JoinSession(ctx context.Context, in *JoinSessionRequest, opts ...grpc.CallOption) (*JoinSessionResponse, error)
+	// StartSession, EndSession, StartQuestion and EndQuestion are only allowed for the quiz master of the session,
+	// or the users with the admin role.
 	StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*StartSessionResponse, error)
 	EndSession(ctx context.Context, in *EndSessionRequest, opts ...grpc.CallOption) (*EndSessionResponse, error)
+	StartQuestion(ctx context.Context, in *StartQuestionRequest, opts ...grpc.CallOption) (*StartQuestionResponse, error)
+	EndQuestion(ctx context.Context, in *EndQuestionRequest, opts ...grpc.CallOption) (*EndQuestionResponse, error)
 	GetCurrentQuestion(ctx context.Context, in *GetCurrentQuestionRequest, opts ...grpc.CallOption) (*GetCurrentQuestionResponse, error)
 	SubmitAnswer(ctx context.Context, in *SubmitAnswerRequest, opts ...grpc.CallOption) (*SubmitAnswerResponse, error)
+	// GetLeaderboard returns the leaderboard of a session.
+	// With the delta leaderboard updates, it has the version of the last published leaderboard.
 	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	// WatchSession streams the live updates of a session to a participant: leaderboard updates,
+	// question start/end and session state changes. After reconnecting, the client can resume
+	// from the sequence of the last received update. If the updates after it are no longer retained, it fails with
+	// FAILED_PRECONDITION and the NOTIFICATIONS_TRUNCATED reason, the client should get the current state and watch again.
+	// With the personalized leaderboard updates, the participant receives personalized_leaderboard instead of
+	// the live leaderboard and leaderboard_delta updates. Without the deltas, the live leaderboard only has the top entries.
+	WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchSessionResponse], error)
+	// ListNotifications returns the retained notifications of a session after a sequence,
+	// so a reconnecting client can fetch the notifications it missed.
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
 }
 
 type quizServiceClient struct {
@@ -59,6 +83,16 @@ func (c *quizServiceClient) CreateSession(ctx context.Context, in *CreateSession
 	return out, nil
 }
 
+func (c *quizServiceClient) JoinSession(ctx context.Context, in *JoinSessionRequest, opts ...grpc.CallOption) (*JoinSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinSessionResponse)
+	err := c.cc.Invoke(ctx, QuizService_JoinSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *quizServiceClient) StartSession(ctx context.Context, in *StartSessionRequest, opts ...grpc.CallOption) (*StartSessionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartSessionResponse)
@@ -79,6 +113,26 @@ func (c *quizServiceClient) EndSession(ctx context.Context, in *EndSessionReques
 	return out, nil
 }
 
+func (c *quizServiceClient) StartQuestion(ctx context.Context, in *StartQuestionRequest, opts ...grpc.CallOption) (*StartQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartQuestionResponse)
+	err := c.cc.Invoke(ctx, QuizService_StartQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) EndQuestion(ctx context.Context, in *EndQuestionRequest, opts ...grpc.CallOption) (*EndQuestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EndQuestionResponse)
+	err := c.cc.Invoke(ctx, QuizService_EndQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *quizServiceClient) GetCurrentQuestion(ctx context.Context, in *GetCurrentQuestionRequest, opts ...grpc.CallOption) (*GetCurrentQuestionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCurrentQuestionResponse)
@@ -109,6 +163,35 @@ func (c *quizServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboa
 	return out, nil
 }
 
+func (c *quizServiceClient) WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchSessionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[0], QuizService_WatchSession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSessionRequest, WatchSessionResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuizService_WatchSessionClient = grpc.ServerStreamingClient[WatchSessionResponse]
+
+func (c *quizServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, QuizService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuizServiceServer is the server API for QuizService service.
 // All implementations must embed UnimplementedQuizServiceServer
 // for forward compatibility.
@@ -116,11 +199,30 @@ type QuizServiceServer interface {
 	// CreateSession a new session, this API is expected to be called by the quiz master.
 	// If this API returns a successful response, the session is created with all the required questions.
 	CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error)
+	// JoinSession adds the caller to the participants of a session, joining a joined session has no effect.
+	// Only the participants, the quiz master and the admins can watch the session and list its notifications.
+	JoinSession(context.Context, *JoinSessionRequest) (*JoinSessionResponse, error)
+	// StartSession, EndSession, StartQuestion and EndQuestion are only allowed for the quiz master of the session,
+	// or the users with the admin role.
 	StartSession(context.Context, *StartSessionRequest) (*StartSessionResponse, error)
 	EndSession(context.Context, *EndSessionRequest) (*EndSessionResponse, error)
+	StartQuestion(context.Context, *StartQuestionRequest) (*StartQuestionResponse, error)
+	EndQuestion(context.Context, *EndQuestionRequest) (*EndQuestionResponse, error)
 	GetCurrentQuestion(context.Context, *GetCurrentQuestionRequest) (*GetCurrentQuestionResponse, error)
 	SubmitAnswer(context.Context, *SubmitAnswerRequest) (*SubmitAnswerResponse, error)
+	// GetLeaderboard returns the leaderboard of a session.
+	// With the delta leaderboard updates, it has the version of the last published leaderboard.
 	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// WatchSession streams the live updates of a session to a participant: leaderboard updates,
+	// question start/end and session state changes. After reconnecting, the client can resume
+	// from the sequence of the last received update. If the updates after it are no longer retained, it fails with
+	// FAILED_PRECONDITION and the NOTIFICATIONS_TRUNCATED reason, the client should get the current state and watch again.
+	// With the personalized leaderboard updates, the participant receives personalized_leaderboard instead of
+	// the live leaderboard and leaderboard_delta updates. Without the deltas, the live leaderboard only has the top entries.
+	WatchSession(*WatchSessionRequest, grpc.ServerStreamingServer[WatchSessionResponse]) error
+	// ListNotifications returns the retained notifications of a session after a sequence,
+	// so a reconnecting client can fetch the notifications it missed.
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
 	mustEmbedUnimplementedQuizServiceServer()
 }
 
@@ -134,12 +236,21 @@ type UnimplementedQuizServiceServer struct{}
 func (UnimplementedQuizServiceServer) CreateSession(context.Context, *CreateSessionRequest) (*CreateSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
 }
+func (UnimplementedQuizServiceServer) JoinSession(context.Context, *JoinSessionRequest) (*JoinSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinSession not implemented")
+}
 func (UnimplementedQuizServiceServer) StartSession(context.Context, *StartSessionRequest) (*StartSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartSession not implemented")
 }
 func (UnimplementedQuizServiceServer) EndSession(context.Context, *EndSessionRequest) (*EndSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EndSession not implemented")
 }
+func (UnimplementedQuizServiceServer) StartQuestion(context.Context, *StartQuestionRequest) (*StartQuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartQuestion not implemented")
+}
+func (UnimplementedQuizServiceServer) EndQuestion(context.Context, *EndQuestionRequest) (*EndQuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndQuestion not implemented")
+}
 func (UnimplementedQuizServiceServer) GetCurrentQuestion(context.Context, *GetCurrentQuestionRequest) (*GetCurrentQuestionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentQuestion not implemented")
 }
@@ -149,6 +260,12 @@ func (UnimplementedQuizServiceServer) SubmitAnswer(context.Context, *SubmitAnswe
 func (UnimplementedQuizServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
 }
+func (UnimplementedQuizServiceServer) WatchSession(*WatchSessionRequest, grpc.ServerStreamingServer[WatchSessionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSession not implemented")
+}
+func (UnimplementedQuizServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
 func (UnimplementedQuizServiceServer) mustEmbedUnimplementedQuizServiceServer() {}
 func (UnimplementedQuizServiceServer) testEmbeddedByValue()                     {}
 
@@ -188,6 +305,24 @@ func _QuizService_CreateSession_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuizService_JoinSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).JoinSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_JoinSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).JoinSession(ctx, req.(*JoinSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QuizService_StartSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartSessionRequest)
 	if err := dec(in); err != nil {
@@ -224,6 +359,42 @@ func _QuizService_EndSession_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuizService_StartQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).StartQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_StartQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).StartQuestion(ctx, req.(*StartQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_EndQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).EndQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_EndQuestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).EndQuestion(ctx, req.(*EndQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QuizService_GetCurrentQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCurrentQuestionRequest)
 	if err := dec(in); err != nil {
@@ -278,6 +449,35 @@ func _QuizService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuizService_WatchSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSessionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).WatchSession(m, &grpc.GenericServerStream[WatchSessionRequest, WatchSessionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuizService_WatchSessionServer = grpc.ServerStreamingServer[WatchSessionResponse]
+
+func _QuizService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QuizService_ServiceDesc is the grpc.ServiceDesc for QuizService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +489,10 @@ var QuizService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSession",
 			Handler:    _QuizService_CreateSession_Handler,
 		},
+		{
+			MethodName: "JoinSession",
+			Handler:    _QuizService_JoinSession_Handler,
+		},
 		{
 			MethodName: "StartSession",
 			Handler:    _QuizService_StartSession_Handler,
@@ -297,6 +501,14 @@ var QuizService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EndSession",
 			Handler:    _QuizService_EndSession_Handler,
 		},
+		{
+			MethodName: "StartQuestion",
+			Handler:    _QuizService_StartQuestion_Handler,
+		},
+		{
+			MethodName: "EndQuestion",
+			Handler:    _QuizService_EndQuestion_Handler,
+		},
 		{
 			MethodName: "GetCurrentQuestion",
 			Handler:    _QuizService_GetCurrentQuestion_Handler,
@@ -309,7 +521,17 @@ var QuizService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLeaderboard",
 			Handler:    _QuizService_GetLeaderboard_Handler,
 		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _QuizService_ListNotifications_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSession",
+			Handler:       _QuizService_WatchSession_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "equiz/v1/equiz.proto",
 }
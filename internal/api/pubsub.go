@@ -5,68 +5,281 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
-	"golang.org/x/sync/errgroup"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/event"
 )
 
-const maxConcurrent = 100
+const (
+	// maxPipelined is the maximum number of commands sent in a pipeline.
+	maxPipelined = 1000
+
+	// defaultMaxNotifications is the default approximate number of recent notifications retained per session for replaying.
+	defaultMaxNotifications = 1000
+	// defaultNotificationsTTL is the default time the notifications of a session are retained after the last one.
+	defaultNotificationsTTL = 24 * time.Hour
+)
+
+// Retention is how the recent notifications of each session are retained for the reconnecting clients.
+type Retention struct {
+	// MaxLen is the approximate number of notifications retained.
+	MaxLen int64
+	// TTL is the time the notifications are retained after the last one.
+	TTL time.Duration
+}
+
+// FanoutMode is how the notifications of a session are delivered to the participants.
+type FanoutMode string
+
+const (
+	// FanoutSession publishes the notifications once to the session channel, the participants subscribe to it.
+	FanoutSession FanoutMode = "session"
+	// FanoutUser also publishes the leaderboard updates to the channel of each user, see LeaderboardUpdates.
+	FanoutUser FanoutMode = "user"
+)
+
+const (
+	SessionStatusStarted = "started"
+	SessionStatusEnded   = "ended"
+
+	QuestionStatusStarted = "started"
+	QuestionStatusEnded   = "ended"
+)
 
 type (
 	Notification struct {
-		Event string `json:"event"`
-		Data  any    `json:"data"`
+		// Sequence is the sequence of the notification within the session, it is monotonically increasing.
+		Sequence  int64  `json:"sequence,omitempty"`
+		SessionID string `json:"session_id,omitempty"`
+		Event     string `json:"event"`
+		Data      any    `json:"data"`
+		NotificationMetadata
+	}
+
+	// NotificationMetadata is the metadata of the event which caused the notification, see event.Metadata.
+	NotificationMetadata struct {
+		EventID       string `json:"event_id,omitempty"`
+		TraceID       string `json:"trace_id,omitempty"`
+		CorrelationID string `json:"correlation_id,omitempty"`
 	}
 
 	Leaderboard struct {
 		SessionID string             `json:"session_id"`
 		Entries   []LeaderboardEntry `json:"entries"`
+		// Version is the version of the published leaderboard, it is only set with the delta leaderboard updates.
+		Version int64 `json:"version,omitempty"`
 	}
 
 	LeaderboardEntry struct {
 		Username string `json:"username"`
 		Score    string `json:"score"`
+		Rank     int    `json:"rank"`
+	}
+
+	SessionUpdate struct {
+		SessionID string `json:"session_id"`
+		Status    string `json:"status"`
+	}
+
+	QuestionUpdate struct {
+		SessionID  string `json:"session_id"`
+		QuestionID string `json:"question_id"`
+		Status     string `json:"status"`
 	}
 )
 
 func (a *API) PublishLeaderboardUpdated(ctx context.Context, e domain.EventLeaderboardUpdated) error {
-	l := e.Leaderboard
+	data := toLeaderboard(e.Leaderboard)
+
+	var (
+		payload string
+		err     error
+	)
+	switch {
+	case a.leaderboardUpdates.Delta:
+		payload, err = a.publishLeaderboardDelta(ctx, data)
+	case a.fanout == FanoutUser && a.leaderboardUpdates.Personalized:
+		// The participants receive their personalized leaderboards, the others only need the top entries.
+		top := data
+		top.Entries = data.Entries[:min(len(data.Entries), a.leaderboardUpdates.TopN)]
+		payload, err = a.publishSessionNotification(ctx, data.SessionID, e.Name(), top)
+	default:
+		payload, err = a.publishSessionNotification(ctx, data.SessionID, e.Name(), data)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Nothing is published if the leaderboard is unchanged.
+	if payload == "" || a.fanout != FanoutUser {
+		return nil
+	}
+
+	if a.leaderboardUpdates.Personalized {
+		return a.publishPersonalizedLeaderboards(ctx, e.Leaderboard, payload)
+	}
+
+	messages := make([]userMessage, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		messages = append(messages, userMessage{user: entry.Username, payload: payload})
+	}
 
+	return a.publishToUsers(ctx, messages)
+}
+
+// publishToUsers publishes the messages to the channels of the users, in pipelines to save the round trips.
+func (a *API) publishToUsers(ctx context.Context, messages []userMessage) error {
+	for len(messages) > 0 {
+		batch := messages[:min(len(messages), maxPipelined)]
+		messages = messages[len(batch):]
+
+		if _, err := a.redis.Pipelined(ctx, func(p redis.Pipeliner) error {
+			for _, m := range batch {
+				p.Publish(ctx, a.userChannel(m.user), m.payload)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("pubsub: publish to users: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toLeaderboard(l domain.Leaderboard) Leaderboard {
 	data := Leaderboard{
 		SessionID: l.SessionID,
 		Entries:   make([]LeaderboardEntry, 0, len(l.Entries)),
 	}
 
-	for _, entry := range l.Entries {
+	for i, entry := range l.Entries {
 		data.Entries = append(data.Entries, LeaderboardEntry{
 			Username: entry.Username,
 			Score:    strconv.FormatFloat(entry.Score, 'f', -1, 64),
+			Rank:     i + 1,
 		})
 	}
 
-	var eg errgroup.Group
-	eg.SetLimit(maxConcurrent)
+	return data
+}
 
-	for _, entry := range data.Entries {
-		eg.Go(func() error {
-			return a.publishNotification(ctx, entry.Username, e.Name(), data)
-		})
-	}
+func (a *API) PublishSessionStarted(ctx context.Context, e domain.EventSessionStarted) error {
+	return a.publishSessionUpdate(ctx, e.Name(), e.Session.SessionID, SessionStatusStarted)
+}
+
+func (a *API) PublishSessionEnded(ctx context.Context, e domain.EventSessionEnded) error {
+	return a.publishSessionUpdate(ctx, e.Name(), e.Session.SessionID, SessionStatusEnded)
+}
+
+func (a *API) publishSessionUpdate(ctx context.Context, event, session, status string) error {
+	_, err := a.publishSessionNotification(ctx, session, event, SessionUpdate{
+		SessionID: session,
+		Status:    status,
+	})
+	return err
+}
+
+func (a *API) PublishQuestionStarted(ctx context.Context, e domain.EventQuestionStarted) error {
+	return a.publishQuestionUpdate(ctx, e.Name(), e.SessionID, e.QuestionID, QuestionStatusStarted)
+}
 
-	return eg.Wait()
+func (a *API) PublishQuestionEnded(ctx context.Context, e domain.EventQuestionEnded) error {
+	return a.publishQuestionUpdate(ctx, e.Name(), e.SessionID, e.QuestionID, QuestionStatusEnded)
 }
 
-func (a *API) publishNotification(ctx context.Context, user, event string, data any) error {
+func (a *API) publishQuestionUpdate(ctx context.Context, event, session, question, status string) error {
+	_, err := a.publishSessionNotification(ctx, session, event, QuestionUpdate{
+		SessionID:  session,
+		QuestionID: question,
+		Status:     status,
+	})
+	return err
+}
+
+// publishLua atomically assigns the next sequence to a notification, then stores and publishes it.
+// KEYS[1]: sequence key, KEYS[2]: notifications stream key.
+// ARGV[1]: notification JSON without sequence, ARGV[2]: max stream length, ARGV[3]: TTL in seconds, ARGV[4]: session channel.
+const publishLua = `
+local seq = redis.call('INCR', KEYS[1])
+local payload = '{"sequence":' .. seq .. ',' .. string.sub(ARGV[1], 2)
+redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[2], seq .. '-0', 'data', payload)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+redis.call('PUBLISH', ARGV[4], payload)
+return payload
+`
+
+var publishScript = redis.NewScript(publishLua)
+
+// publishSessionNotification publishes a notification to the session channel, and returns the published payload.
+func (a *API) publishSessionNotification(ctx context.Context, session, event string, data any) (string, error) {
+	return a.runPublishScript(ctx, publishScript, session, event, data, nil)
+}
+
+// runPublishScript runs a script extending publishLua with the keys and args.
+func (a *API) runPublishScript(ctx context.Context, script *redis.Script, session, event string, data any, keys []string, args ...any) (string, error) {
 	n := Notification{
-		Event: event,
-		Data:  data,
+		SessionID:            session,
+		Event:                event,
+		Data:                 data,
+		NotificationMetadata: notificationMetadata(ctx),
 	}
 
 	b, err := json.Marshal(n)
 	if err != nil {
-		return fmt.Errorf("pubsub: marshal %s: %v", event, err)
+		return "", fmt.Errorf("pubsub: marshal %s: %v", event, err)
+	}
+
+	payload, err := script.Run(ctx, a.redis,
+		append([]string{a.sequenceKey(session), a.notificationsKey(session)}, keys...),
+		append([]any{b, a.retention.MaxLen, int(a.retention.TTL.Seconds()), a.sessionChannel(session)}, args...)...,
+	).Text()
+	if err != nil {
+		return "", fmt.Errorf("pubsub: publish %s: session=%s: %w", event, session, err)
 	}
 
-	return a.redis.Publish(ctx, fmt.Sprintf("%s:user:%s", a.prefix, user), b).Err()
+	return payload, nil
+}
+
+// notificationMetadata returns the metadata of the event being handled, if any.
+func notificationMetadata(ctx context.Context) NotificationMetadata {
+	env, ok := event.EnvelopeFromContext(ctx)
+	if !ok {
+		return NotificationMetadata{}
+	}
+
+	return NotificationMetadata{
+		EventID:       env.ID,
+		TraceID:       env.TraceID,
+		CorrelationID: env.CorrelationID,
+	}
+}
+
+func (a *API) userChannel(user string) string {
+	return fmt.Sprintf("%s:user:%s", a.prefix, user)
+}
+
+func (a *API) sessionChannel(session string) string {
+	return fmt.Sprintf("%s:session:%s", a.prefix, session)
+}
+
+// The keys of a session are hash-tagged by the session, so the scripts using them work with Redis Cluster.
+
+func (a *API) sequenceKey(session string) string {
+	return fmt.Sprintf("%s:session:{%s}:sequence", a.prefix, session)
+}
+
+func (a *API) notificationsKey(session string) string {
+	return fmt.Sprintf("%s:session:{%s}:notifications", a.prefix, session)
+}
+
+func (a *API) leaderboardKey(session string) string {
+	return fmt.Sprintf("%s:session:{%s}:leaderboard", a.prefix, session)
+}
+
+func (a *API) ranksKey(session string) string {
+	return fmt.Sprintf("%s:session:{%s}:ranks", a.prefix, session)
 }
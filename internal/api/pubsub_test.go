@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/event"
+)
+
+func TestAPI_PublishSessionStarted(t *testing.T) {
+	a := newTestAPI(t, api.Config{})
+	ch := a.subscribe(t, prefix+":session:s1")
+
+	ctx := event.WithCorrelationID(context.Background(), "r1")
+	require.NoError(t, a.bus.Publish(ctx, domain.EventSessionStarted{Session: domain.Session{SessionID: "s1"}}))
+
+	var n struct {
+		api.Notification
+		Data api.SessionUpdate `json:"data"`
+	}
+	select {
+	case msg := <-ch:
+		require.NoError(t, json.Unmarshal([]byte(msg.Payload), &n))
+	case <-time.After(time.Second):
+		t.Fatal("notification not published")
+	}
+
+	require.Equal(t, int64(1), n.Sequence)
+	require.Equal(t, domain.EventNameSessionStarted, n.Event)
+	require.Equal(t, api.SessionUpdate{SessionID: "s1", Status: api.SessionStatusStarted}, n.Data)
+	require.NotEmpty(t, n.EventID, "the notification should have the ID of the event")
+	require.Equal(t, "r1", n.CorrelationID)
+}
+
+func TestAPI_PublishLeaderboardUpdated_Fanout(t *testing.T) {
+	tests := map[string]struct {
+		fanout       api.FanoutMode
+		wantChannels []string
+	}{
+		"session fan-out should only publish to the session channel": {
+			fanout:       api.FanoutSession,
+			wantChannels: []string{prefix + ":session:s1"},
+		},
+		"user fan-out should also publish to the channels of the users in the leaderboard": {
+			fanout:       api.FanoutUser,
+			wantChannels: []string{prefix + ":session:s1", prefix + ":user:u1", prefix + ":user:u2"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{Fanout: tt.fanout})
+			ch := a.subscribe(t, prefix+":session:s1", prefix+":user:u1", prefix+":user:u2", prefix+":user:u3")
+
+			require.NoError(t, a.PublishLeaderboardUpdated(context.Background(), domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+				SessionID: "s1",
+				Entries:   []domain.LeaderboardEntry{{Username: "u1", Score: 2}, {Username: "u2", Score: 1}},
+			}}))
+
+			var (
+				channels []string
+				payloads = map[string]bool{}
+			)
+			for range tt.wantChannels {
+				select {
+				case msg := <-ch:
+					channels = append(channels, msg.Channel)
+					payloads[msg.Payload] = true
+				case <-time.After(time.Second):
+					t.Fatalf("notification not published, received on %v", channels)
+				}
+			}
+
+			select {
+			case msg := <-ch:
+				t.Fatalf("unexpected notification on %s", msg.Channel)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			require.ElementsMatch(t, tt.wantChannels, channels)
+			require.Len(t, payloads, 1, "the same notification should be published to all channels")
+
+			var n struct {
+				api.Notification
+				Data api.Leaderboard `json:"data"`
+			}
+			for p := range payloads {
+				require.NoError(t, json.Unmarshal([]byte(p), &n))
+			}
+			require.Equal(t, int64(1), n.Sequence)
+			require.Equal(t, []api.LeaderboardEntry{
+				{Username: "u1", Score: "2", Rank: 1},
+				{Username: "u2", Score: "1", Rank: 2},
+			}, n.Data.Entries)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/ratelimit"
+)
+
+// maxRequestBody is the maximum size of the bodies of the REST requests.
+const maxRequestBody = 1 << 20
+
+var (
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// registerREST registers the REST/JSON equivalents of the unary gRPC APIs.
+func (a *API) registerREST(r gin.IRouter) {
+	limit := func(rpc string) gin.HandlerFunc {
+		return ratelimit.Middleware(a.limiter, rpc)
+	}
+
+	r.POST("/sessions", limit("CreateSession"), unary(a.CreateSession, nil))
+	r.POST("/sessions/:id/join", limit("JoinSession"), unary(a.JoinSession, func(c *gin.Context, req *equizv1.JoinSessionRequest) error {
+		req.SessionId = c.Param("id")
+		return nil
+	}))
+	r.POST("/sessions/:id/start", limit("StartSession"), unary(a.StartSession, func(c *gin.Context, req *equizv1.StartSessionRequest) error {
+		req.SessionId = c.Param("id")
+		return nil
+	}))
+	r.POST("/sessions/:id/end", limit("EndSession"), unary(a.EndSession, func(c *gin.Context, req *equizv1.EndSessionRequest) error {
+		req.SessionId = c.Param("id")
+		return nil
+	}))
+	r.POST("/sessions/:id/questions/:question_id/start", limit("StartQuestion"), unary(a.StartQuestion, func(c *gin.Context, req *equizv1.StartQuestionRequest) error {
+		req.SessionId = c.Param("id")
+		req.QuestionId = c.Param("question_id")
+		return nil
+	}))
+	r.POST("/sessions/:id/questions/:question_id/end", limit("EndQuestion"), unary(a.EndQuestion, func(c *gin.Context, req *equizv1.EndQuestionRequest) error {
+		req.SessionId = c.Param("id")
+		req.QuestionId = c.Param("question_id")
+		return nil
+	}))
+	r.GET("/sessions/:id/questions/current", limit("GetCurrentQuestion"), unary(a.GetCurrentQuestion, nil))
+	r.POST("/sessions/:id/answers", limit("SubmitAnswer"), unary(a.SubmitAnswer, func(c *gin.Context, req *equizv1.SubmitAnswerRequest) error {
+		req.SessionId = c.Param("id")
+		return nil
+	}))
+	r.GET("/sessions/:id/leaderboard", limit("GetLeaderboard"), unary(a.GetLeaderboard, func(c *gin.Context, req *equizv1.GetLeaderboardRequest) error {
+		req.SessionId = c.Param("id")
+		return nil
+	}))
+	r.GET("/sessions/:id/notifications", limit("ListNotifications"), unary(a.ListNotifications, bindListNotifications))
+}
+
+// unary returns a gin handler calling a unary gRPC handler with the JSON body, then bind sets the path fields.
+func unary[Req any, Resp proto.Message, PReq interface {
+	*Req
+	proto.Message
+}](h func(context.Context, PReq) (Resp, error), bind func(*gin.Context, PReq) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := PReq(new(Req))
+
+		b, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBody))
+
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("body is larger than %d bytes", tooLarge.Limit)))
+			return
+		}
+		if err != nil {
+			renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("read body: %v", err)))
+			return
+		}
+
+		if len(b) > 0 {
+			if err := unmarshalOptions.Unmarshal(b, req); err != nil {
+				renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("invalid body: %v", err)))
+				return
+			}
+		}
+
+		if bind != nil {
+			if err := bind(c, req); err != nil {
+				renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("%v", err)))
+				return
+			}
+		}
+
+		resp, err := h(c.Request.Context(), req)
+		if err != nil {
+			renderError(c, err)
+			return
+		}
+
+		renderProto(c, http.StatusOK, resp)
+	}
+}
+
+func bindListNotifications(c *gin.Context, req *equizv1.ListNotificationsRequest) error {
+	req.SessionId = c.Param("id")
+
+	if v := c.Query("after_sequence"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid after_sequence: %s", v)
+		}
+		req.AfterSequence = n
+	}
+
+	if v := c.Query("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid page_size: %s", v)
+		}
+		req.PageSize = int32(n)
+	}
+
+	return nil
+}
+
+func renderProto(c *gin.Context, code int, m proto.Message) {
+	b, err := marshalOptions.Marshal(m)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Data(code, "application/json", b)
+}
+
+// watchSession serves WatchSession with Server-Sent Events, resuming from Last-Event-ID.
+func (a *API) watchSession(c *gin.Context) {
+	req := &equizv1.WatchSessionRequest{
+		SessionId: c.Param("id"),
+	}
+
+	from := c.GetHeader("Last-Event-ID")
+	if from == "" {
+		from = c.Query("from_sequence")
+	}
+
+	if from != "" {
+		seq, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("invalid sequence: %s", from)))
+			return
+		}
+		req.FromSequence = seq
+	}
+
+	stream := &sseWatchStream{c: c}
+	if err := a.WatchSession(req, stream); err != nil {
+		// The error can only be rendered as the response before the stream is started.
+		if !c.Writer.Written() {
+			renderError(c, err)
+		}
+	}
+}
+
+// sseWatchStream sends the responses of WatchSession as Server-Sent Events.
+type sseWatchStream struct {
+	grpc.ServerStream
+	c *gin.Context
+}
+
+func (s *sseWatchStream) Context() context.Context {
+	return s.c.Request.Context()
+}
+
+func (s *sseWatchStream) SendHeader(metadata.MD) error {
+	startEventStream(s.c)
+	return nil
+}
+
+func (s *sseWatchStream) Send(resp *equizv1.WatchSessionResponse) error {
+	b, err := marshalOptions.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	s.c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(resp.Sequence, 10),
+		Event: resp.Event,
+		Data:  string(b),
+	})
+	s.c.Writer.Flush()
+
+	return s.c.Request.Context().Err()
+}
@@ -0,0 +1,113 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestAPI_REST(t *testing.T) {
+	tests := map[string]struct {
+		user       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantReason string
+	}{
+		"should join the session": {
+			user:       "u3",
+			method:     http.MethodPost,
+			path:       "/sessions/s1/join",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"session":{"session_id":"s1","quiz_master":"qm","question_ids":[]}}`,
+		},
+		"should return not found for unknown sessions": {
+			user:       "u1",
+			method:     http.MethodPost,
+			path:       "/sessions/s2/join",
+			wantStatus: http.StatusNotFound,
+			wantReason: errors.ReasonSessionNotFound,
+		},
+		"should only allow the quiz master to start the session": {
+			user:       "u1",
+			method:     http.MethodPost,
+			path:       "/sessions/s1/start",
+			body:       `{}`,
+			wantStatus: http.StatusForbidden,
+			wantReason: errors.ReasonNotQuizMaster,
+		},
+		"should reject invalid bodies": {
+			user:       "qm",
+			method:     http.MethodPost,
+			path:       "/sessions/s1/start",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"should reject bodies larger than the limit": {
+			user:       "qm",
+			method:     http.MethodPost,
+			path:       "/sessions/s1/start",
+			body:       `{"request_id":"` + strings.Repeat("a", 1<<20) + `"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"should deny submitting answers to the sessions not joined": {
+			user:       "u3",
+			method:     http.MethodPost,
+			path:       "/sessions/s1/answers",
+			body:       `{"question_id":"q1","answer":"a"}`,
+			wantStatus: http.StatusForbidden,
+			wantReason: errors.ReasonNotParticipant,
+		},
+		"should deny getting the leaderboard of the sessions not joined": {
+			user:       "u3",
+			method:     http.MethodGet,
+			path:       "/sessions/s1/leaderboard",
+			wantStatus: http.StatusForbidden,
+			wantReason: errors.ReasonNotParticipant,
+		},
+		"should reject the unauthenticated requests": {
+			method:     http.MethodPost,
+			path:       "/sessions/s1/join",
+			wantStatus: http.StatusUnauthorized,
+			wantReason: errors.ReasonTokenMissing,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.user != "" {
+				req.Header.Set("Authorization", "Bearer "+token(t, tt.user))
+			}
+			rec := httptest.NewRecorder()
+			a.http.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+
+			if tt.wantReason != "" {
+				var e errors.Error
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &e))
+				assert.Equal(t, tt.wantReason, e.Reason)
+			}
+		})
+	}
+}
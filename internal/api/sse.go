@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// sseKeepAlive is the interval of the comments sent to keep idle connections open through proxies.
+const sseKeepAlive = 15 * time.Second
+
+// streamLeaderboard streams the leaderboard of a session with Server-Sent Events, resuming from Last-Event-ID.
+func (a *API) streamLeaderboard(c *gin.Context) {
+	session := c.Param("id")
+
+	var from int64
+	if id := c.GetHeader("Last-Event-ID"); id != "" {
+		var err error
+		if from, err = strconv.ParseInt(id, 10, 64); err != nil {
+			renderError(c, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("invalid Last-Event-ID: %s", id)))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	p, err := principal(ctx)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	if _, err := a.authorizeMember(ctx, p, session); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	w, err := a.watch(ctx, session, "", from)
+	// The missed updates are no longer retained, the current leaderboard is sent instead.
+	if isTruncated(err) {
+		from = 0
+		w, err = a.watch(ctx, session, "", from)
+	}
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	defer w.Close()
+
+	var snapshot *Notification
+	if from <= 0 {
+		n, err := a.leaderboardSnapshot(ctx, session)
+		if err != nil {
+			renderError(c, err)
+			return
+		}
+
+		// Updates before the snapshot are already included in it.
+		w.last = n.Sequence
+		snapshot = &n
+	}
+
+	startEventStream(c)
+
+	if snapshot != nil {
+		c.Render(-1, sse.Event{
+			Id:    strconv.FormatInt(snapshot.Sequence, 10),
+			Event: snapshot.Event,
+			Data:  snapshot,
+		})
+		c.Writer.Flush()
+	}
+
+	notifications := make(chan rawNotification)
+	go func() {
+		defer close(notifications)
+		for {
+			n, err := w.Next(ctx)
+			if err != nil {
+				return
+			}
+
+			select {
+			case notifications <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			_, _ = c.Writer.WriteString(": keep-alive\n\n")
+
+		case n, ok := <-notifications:
+			if !ok {
+				return
+			}
+
+			if !isLeaderboardUpdate(n.Event) {
+				continue
+			}
+
+			c.Render(-1, sse.Event{
+				Id:    strconv.FormatInt(n.Sequence, 10),
+				Event: n.Event,
+				Data:  n.payload,
+			})
+		}
+
+		c.Writer.Flush()
+	}
+}
+
+// leaderboardSnapshot returns the current leaderboard with the sequence of the last notification before it.
+func (a *API) leaderboardSnapshot(ctx context.Context, session string) (Notification, error) {
+	// The sequence is read first, so no delta after the published leaderboard is skipped.
+	seq, err := a.currentSequence(ctx, session)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	data, err := a.currentLeaderboard(ctx, session)
+	switch {
+	case err != nil && errors.Convert(err).Code == errors.CodeNotFound:
+		data = Leaderboard{
+			SessionID: session,
+			Entries:   []LeaderboardEntry{},
+		}
+	case err != nil:
+		return Notification{}, err
+	}
+
+	return Notification{
+		Sequence:  seq,
+		SessionID: session,
+		Event:     domain.EventNameLeaderboardUpdated,
+		Data:      data,
+	}, nil
+}
+
+// startEventStream writes the headers of a Server-Sent Events stream, errors can't be rendered after it.
+func startEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+}
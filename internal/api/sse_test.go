@@ -0,0 +1,195 @@
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/domain"
+)
+
+func TestAPI_StreamLeaderboard(t *testing.T) {
+	a := newTestAPI(t, api.Config{Fanout: api.FanoutSession})
+	a.updateScores(t, s1, map[string]float64{"u1": 2, "u2": 1})
+
+	resp, events := a.streamLeaderboard(t, "u1", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	e := next(t, events)
+	assert.Equal(t, domain.EventNameLeaderboardUpdated, e.event)
+	assert.Equal(t, "0", e.id, "the snapshot should have the sequence of the last notification")
+	assert.Equal(t, []api.LeaderboardEntry{
+		{Username: "u1", Score: "2", Rank: 1},
+		{Username: "u2", Score: "1", Rank: 2},
+	}, e.leaderboard(t).Entries)
+
+	require.NoError(t, a.PublishLeaderboardUpdated(context.Background(), domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+		SessionID: s1,
+		Entries:   []domain.LeaderboardEntry{{Username: "u2", Score: 3}, {Username: "u1", Score: 2}},
+	}}))
+
+	e = next(t, events)
+	assert.Equal(t, "1", e.id)
+	assert.Equal(t, "u2", e.leaderboard(t).Entries[0].Username)
+}
+
+func TestAPI_StreamLeaderboard_Resume(t *testing.T) {
+	tests := map[string]struct {
+		trim   bool
+		wantID []string
+	}{
+		"should send the missed updates": {
+			wantID: []string{"2", "3"},
+		},
+		"should send the snapshot if the missed updates are no longer retained": {
+			trim:   true,
+			wantID: []string{"3"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{Fanout: api.FanoutSession})
+			a.updateScores(t, s1, map[string]float64{"u1": 1})
+
+			ctx := context.Background()
+			for i := 1; i <= 3; i++ {
+				require.NoError(t, a.PublishLeaderboardUpdated(ctx, domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+					SessionID: s1,
+					Entries:   []domain.LeaderboardEntry{{Username: "u1", Score: float64(i)}},
+				}}))
+			}
+
+			if tt.trim {
+				require.NoError(t, a.redis.XDel(ctx, prefix+":session:{s1}:notifications", "1-0", "2-0").Err())
+			}
+
+			_, events := a.streamLeaderboard(t, "u1", "1")
+			for _, id := range tt.wantID {
+				assert.Equal(t, id, next(t, events).id)
+			}
+		})
+	}
+}
+
+func TestAPI_StreamLeaderboard_Unauthorized(t *testing.T) {
+	tests := map[string]struct {
+		user       string
+		wantStatus int
+	}{
+		"should reject the unauthenticated requests": {
+			wantStatus: http.StatusUnauthorized,
+		},
+		"should deny the users not in the session": {
+			user:       "u3",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{})
+
+			resp, _ := a.streamLeaderboard(t, tt.user, "")
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+type sseEvent struct {
+	id, event, data string
+}
+
+func (e sseEvent) leaderboard(t *testing.T) api.Leaderboard {
+	t.Helper()
+
+	var n struct {
+		Data api.Leaderboard `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(e.data), &n))
+
+	return n.Data
+}
+
+// streamLeaderboard requests the leaderboard stream of s1 as the user with the access_token query,
+// the events of the response are sent to the channel.
+func (a testAPI) streamLeaderboard(t *testing.T, user, lastEventID string) (*http.Response, <-chan sseEvent) {
+	t.Helper()
+
+	srv := httptest.NewServer(a.http)
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	url := srv.URL + "/sessions/s1/leaderboard/stream"
+	if user != "" {
+		url += "?access_token=" + token(t, user)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	events := make(chan sseEvent, 100)
+	go func() {
+		defer close(events)
+
+		var (
+			e       sseEvent
+			scanner = bufio.NewScanner(resp.Body)
+		)
+		for scanner.Scan() {
+			k, v, _ := strings.Cut(scanner.Text(), ":")
+			switch k {
+			case "id":
+				e.id = v
+			case "event":
+				e.event = v
+			case "data":
+				e.data = v
+			case "":
+				if e.event != "" {
+					events <- e
+				}
+				e = sseEvent{}
+			}
+		}
+	}()
+
+	return resp, events
+}
+
+func next(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		require.True(t, ok, "the stream should not end")
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("event not sent")
+		return sseEvent{}
+	}
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// WatchSession streams the notifications of a session from the pub/sub channels.
+func (a *API) WatchSession(req *equizv1.WatchSessionRequest, stream equizv1.QuizService_WatchSessionServer) error {
+	ctx := stream.Context()
+
+	p, err := principal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeSelf(p, req.Username); err != nil { //nolint:staticcheck // The deprecated field is checked for compatibility.
+		return err
+	}
+
+	if req.SessionId == "" {
+		return errors.InvalidField("session_id", "is required")
+	}
+
+	ss, err := a.authorizeMember(ctx, p, req.SessionId)
+	if err != nil {
+		return err
+	}
+
+	// Only the participants receive notifications on their own channels.
+	var user string
+	if slices.Contains(ss.Participants, p.Username) {
+		user = p.Username
+	}
+
+	w, err := a.watch(ctx, req.SessionId, user, req.FromSequence)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	// Send the headers, so the client knows the subscription is established.
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		n, err := w.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		resp, ok, err := toWatchSessionResponse(n)
+		if err != nil {
+			slog.ErrorContext(ctx, "api: convert notification failed",
+				"session", req.SessionId,
+				"sequence", n.Sequence,
+				"error", err,
+			)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// personalizedWait is how long a leaderboard update of the session channel waits for the personalized one of the user.
+const personalizedWait = time.Second
+
+// rawNotification is a Notification whose data is not decoded yet.
+type rawNotification struct {
+	Sequence  int64           `json:"sequence"`
+	SessionID string          `json:"session_id"`
+	Event     string          `json:"event"`
+	Data      json.RawMessage `json:"data"`
+	NotificationMetadata
+	payload string
+}
+
+// watcher reads the notifications of a session and of the user in the order of their sequences.
+type watcher struct {
+	session string
+	sub     *redis.PubSub
+	ch      <-chan *redis.Message
+	// received are the messages received while waiting for the subscriptions, they are read before the channel.
+	received []*redis.Message
+	replay   []rawNotification
+	last     int64
+
+	// personalized replaces the leaderboard updates of the session channel with the ones of the user channel.
+	personalized   bool
+	user           string
+	sessionChannel string
+	pending        *rawNotification
+	// deferred are the messages received while an update is pending, they are read after it.
+	deferred []*redis.Message
+	// wait expires the pending update, it is nil if none is pending.
+	wait <-chan time.Time
+}
+
+// watch subscribes to the notifications of a session after the given sequence, if they are still retained.
+func (a *API) watch(ctx context.Context, session, user string, from int64) (*watcher, error) {
+	channels := []string{a.sessionChannel(session)}
+	if user != "" {
+		channels = append(channels, a.userChannel(user))
+	}
+
+	sub := a.redis.Subscribe(ctx, channels...)
+
+	w := &watcher{
+		session: session,
+		sub:     sub,
+		last:    from,
+
+		personalized:   user != "" && a.fanout == FanoutUser && a.leaderboardUpdates.Personalized,
+		user:           user,
+		sessionChannel: a.sessionChannel(session),
+	}
+
+	// Wait for the subscriptions of all the channels to be confirmed before replaying, so no notification is missed in between.
+	for confirmed := 0; confirmed < len(channels); {
+		msg, err := sub.Receive(ctx)
+		if err != nil {
+			_ = sub.Close()
+			return nil, fmt.Errorf("pubsub: subscribe: session=%s: %w", session, err)
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			confirmed++
+		case *redis.Message:
+			w.received = append(w.received, msg)
+		}
+	}
+	w.ch = sub.Channel()
+
+	if from <= 0 {
+		return w, nil
+	}
+
+	// The sequence is read before the notifications, the ones published after it are received from the channels.
+	seq, err := a.currentSequence(ctx, session)
+	if err != nil {
+		_ = sub.Close()
+		return nil, err
+	}
+
+	replay, err := a.readNotifications(ctx, session, from, 0)
+	if err != nil {
+		_ = sub.Close()
+		return nil, err
+	}
+
+	if (len(replay) > 0 && replay[0].Sequence > from+1) || (len(replay) == 0 && seq > from) {
+		_ = sub.Close()
+
+		// Without any retained notification, the oldest one is the next.
+		oldest := seq + 1
+		if len(replay) > 0 {
+			oldest = replay[0].Sequence
+		}
+
+		return nil, errors.New(errors.CodeFailedPrecondition,
+			errors.WithMessagef("notifications are no longer retained: session=%s from_sequence=%d", session, from),
+			errors.WithReason(errors.ReasonNotificationsTruncated, "session_id", session, "sequence", strconv.FormatInt(seq, 10)),
+			errors.WithPreconditionViolation("NOTIFICATION_RETENTION", session,
+				fmt.Sprintf("the oldest retained sequence is %d", oldest)),
+		)
+	}
+	w.replay = replay
+
+	return w, nil
+}
+
+// isTruncated reports whether the error is returned by watch for the notifications no longer retained.
+func isTruncated(err error) bool {
+	e := errors.Convert(err)
+	return e.Code == errors.CodeFailedPrecondition && e.Reason == errors.ReasonNotificationsTruncated
+}
+
+// Next returns the next notification of the session, notifications already returned are skipped.
+func (w *watcher) Next(ctx context.Context) (rawNotification, error) {
+	for {
+		var (
+			n   rawNotification
+			msg *redis.Message
+			err error
+		)
+		if len(w.replay) > 0 {
+			n, w.replay = w.replay[0], w.replay[1:]
+		} else {
+			if msg, err = w.receive(ctx); err != nil {
+				return rawNotification{}, err
+			}
+
+			if msg == nil {
+				// The personalized update isn't received in time, return the one of the session channel instead.
+				n = w.release()
+				w.last = n.Sequence
+				return n, nil
+			}
+
+			if n, err = decodeNotification(msg.Payload); err != nil {
+				slog.ErrorContext(ctx, "api: decode notification failed", "channel", msg.Channel, "error", err)
+				continue
+			}
+		}
+
+		// The user channel also receives the notifications of other sessions,
+		// and a notification can be received from both channels.
+		if n.SessionID != w.session || n.Sequence <= w.last {
+			continue
+		}
+
+		if w.hold(msg, n) {
+			continue
+		}
+
+		w.last = n.Sequence
+		return n, nil
+	}
+}
+
+// hold reports whether the notification is held back until the personalized leaderboard update is received.
+func (w *watcher) hold(msg *redis.Message, n rawNotification) bool {
+	if w.pending == nil {
+		if msg == nil || !w.personalized || msg.Channel != w.sessionChannel || !isLeaderboardUpdate(n.Event) {
+			return false
+		}
+
+		w.pending = &n
+		w.wait = time.After(personalizedWait)
+		return true
+	}
+
+	switch {
+	case n.Sequence > w.pending.Sequence:
+		// Don't skip the pending sequence, its personalized update may still be received.
+		w.deferred = append(w.deferred, msg)
+		return true
+	case n.Sequence < w.pending.Sequence || n.Event != NotificationLeaderboardPersonalized:
+		return true
+	}
+
+	w.release()
+	return false
+}
+
+// release returns the pending update and clears it, the deferred messages are read next.
+func (w *watcher) release() rawNotification {
+	n := *w.pending
+	w.pending, w.wait = nil, nil
+	w.received = append(w.deferred, w.received...)
+	w.deferred = nil
+
+	return n
+}
+
+// receive returns the next message of the channels, or nil if the pending update expires.
+func (w *watcher) receive(ctx context.Context) (*redis.Message, error) {
+	if len(w.received) > 0 {
+		msg := w.received[0]
+		w.received = w.received[1:]
+		return msg, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-w.wait:
+		return nil, nil
+	case msg, ok := <-w.ch:
+		if !ok {
+			return nil, fmt.Errorf("pubsub: subscription closed: session=%s", w.session)
+		}
+		return msg, nil
+	}
+}
+
+func (w *watcher) Close() error {
+	return w.sub.Close()
+}
+
+func isLeaderboardUpdate(event string) bool {
+	return event == domain.EventNameLeaderboardUpdated || event == NotificationLeaderboardDelta
+}
+
+func decodeNotification(payload string) (rawNotification, error) {
+	var n rawNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return rawNotification{}, err
+	}
+
+	n.payload = payload
+	return n, nil
+}
+
+// toWatchSessionResponse converts a notification to a response, it returns false for unknown events.
+func toWatchSessionResponse(n rawNotification) (*equizv1.WatchSessionResponse, bool, error) {
+	resp := &equizv1.WatchSessionResponse{
+		Sequence:      n.Sequence,
+		Event:         n.Event,
+		EventId:       n.EventID,
+		TraceId:       n.TraceID,
+		CorrelationId: n.CorrelationID,
+	}
+
+	switch n.Event {
+	case domain.EventNameLeaderboardUpdated:
+		var l Leaderboard
+		if err := json.Unmarshal(n.Data, &l); err != nil {
+			return nil, false, err
+		}
+
+		pl, err := toProtoLeaderboard(l)
+		if err != nil {
+			return nil, false, err
+		}
+		resp.Data = &equizv1.WatchSessionResponse_Leaderboard{Leaderboard: pl}
+
+	case NotificationLeaderboardDelta:
+		var d LeaderboardDelta
+		if err := json.Unmarshal(n.Data, &d); err != nil {
+			return nil, false, err
+		}
+
+		entries, err := toProtoLeaderboardEntries(d.Entries)
+		if err != nil {
+			return nil, false, err
+		}
+		resp.Data = &equizv1.WatchSessionResponse_LeaderboardDelta{LeaderboardDelta: &equizv1.LeaderboardDelta{
+			SessionId: d.SessionID,
+			Version:   d.Version,
+			Entries:   entries,
+		}}
+
+	case NotificationLeaderboardPersonalized:
+		var p PersonalizedLeaderboard
+		if err := json.Unmarshal(n.Data, &p); err != nil {
+			return nil, false, err
+		}
+
+		pl, err := toProtoPersonalizedLeaderboard(p)
+		if err != nil {
+			return nil, false, err
+		}
+		resp.Data = &equizv1.WatchSessionResponse_PersonalizedLeaderboard{PersonalizedLeaderboard: pl}
+
+	case domain.EventNameSessionStarted, domain.EventNameSessionEnded:
+		status := equizv1.SessionStatus_SESSION_STATUS_STARTED
+		if n.Event == domain.EventNameSessionEnded {
+			status = equizv1.SessionStatus_SESSION_STATUS_ENDED
+		}
+		resp.Data = &equizv1.WatchSessionResponse_Session{Session: &equizv1.SessionUpdate{Status: status}}
+
+	case domain.EventNameQuestionStarted, domain.EventNameQuestionEnded:
+		var q QuestionUpdate
+		if err := json.Unmarshal(n.Data, &q); err != nil {
+			return nil, false, err
+		}
+
+		status := equizv1.QuestionStatus_QUESTION_STATUS_STARTED
+		if n.Event == domain.EventNameQuestionEnded {
+			status = equizv1.QuestionStatus_QUESTION_STATUS_ENDED
+		}
+		resp.Data = &equizv1.WatchSessionResponse_Question{Question: &equizv1.QuestionUpdate{
+			QuestionId: q.QuestionID,
+			Status:     status,
+		}}
+
+	default:
+		return nil, false, nil
+	}
+
+	return resp, true, nil
+}
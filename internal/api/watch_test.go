@@ -0,0 +1,205 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestAPI_WatchSession(t *testing.T) {
+	a := newTestAPI(t, api.Config{Fanout: api.FanoutSession})
+	ctx := context.Background()
+
+	for _, q := range []string{"q1", "q2", "q3"} {
+		require.NoError(t, a.PublishQuestionStarted(ctx, domain.EventQuestionStarted{SessionID: s1, QuestionID: q}))
+	}
+
+	stream, _ := a.watch(t, userContext("u1"), &equizv1.WatchSessionRequest{SessionId: s1, FromSequence: 1})
+
+	require.NoError(t, a.PublishQuestionStarted(ctx, domain.EventQuestionStarted{SessionID: s1, QuestionID: "q4"}))
+
+	for i, q := range []string{"q2", "q3", "q4"} {
+		resp := stream.next(t)
+		assert.Equal(t, int64(i+2), resp.Sequence, "the retained notifications after the sequence should be replayed, then the live ones")
+		assert.Equal(t, q, resp.GetQuestion().QuestionId)
+	}
+}
+
+func TestAPI_WatchSession_UserChannel(t *testing.T) {
+	a := newTestAPI(t, api.Config{})
+
+	stream, _ := a.watch(t, userContext("u1"), &equizv1.WatchSessionRequest{SessionId: s1})
+
+	// Both the session and the user channels are subscribed once the stream is started.
+	payload := `{"sequence":1,"session_id":"s1","event":"session.started","data":{"session_id":"s1","status":"started"}}`
+	require.NoError(t, a.redis.Publish(context.Background(), prefix+":user:u1", payload).Err())
+
+	resp := stream.next(t)
+	assert.Equal(t, int64(1), resp.Sequence)
+	assert.Equal(t, equizv1.SessionStatus_SESSION_STATUS_STARTED, resp.GetSession().Status)
+}
+
+func TestAPI_WatchSession_Personalized(t *testing.T) {
+	type want struct {
+		sequence int64
+		event    string
+	}
+
+	publishLeaderboard := func(t *testing.T, a testAPI) {
+		require.NoError(t, a.PublishLeaderboardUpdated(context.Background(), domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+			SessionID: s1,
+			Entries:   []domain.LeaderboardEntry{{Username: "u1", Score: 2}},
+		}}))
+	}
+
+	tests := map[string]struct {
+		user    string
+		publish func(t *testing.T, a testAPI)
+		want    []want
+	}{
+		"should send the personalized leaderboard instead of the one of the session": {
+			user:    "u1",
+			publish: publishLeaderboard,
+			want:    []want{{sequence: 1, event: api.NotificationLeaderboardPersonalized}},
+		},
+		"should send the personalized leaderboard to the participants without a score": {
+			user:    "u2",
+			publish: publishLeaderboard,
+			want:    []want{{sequence: 1, event: api.NotificationLeaderboardPersonalized}},
+		},
+		"should send the leaderboard of the session to the quiz master": {
+			user:    "qm",
+			publish: publishLeaderboard,
+			want:    []want{{sequence: 1, event: domain.EventNameLeaderboardUpdated}},
+		},
+		"should send the leaderboard of the session if the personalized one isn't received in time": {
+			user: "u1",
+			publish: func(t *testing.T, a testAPI) {
+				require.NoError(t, a.redis.Publish(context.Background(), prefix+":session:s1",
+					`{"sequence":1,"session_id":"s1","event":"leaderboard.updated","data":{"session_id":"s1","entries":[]}}`).Err())
+			},
+			want: []want{{sequence: 1, event: domain.EventNameLeaderboardUpdated}},
+		},
+		"should not skip the pending leaderboard if the next notification is received first": {
+			user: "u1",
+			publish: func(t *testing.T, a testAPI) {
+				ctx := context.Background()
+				for _, m := range []struct{ channel, payload string }{
+					{
+						channel: prefix + ":session:s1",
+						payload: `{"sequence":1,"session_id":"s1","event":"leaderboard.updated","data":{"session_id":"s1","entries":[{"username":"u1","score":"2","rank":1}]}}`,
+					},
+					{
+						channel: prefix + ":session:s1",
+						payload: `{"sequence":2,"session_id":"s1","event":"question.started","data":{"session_id":"s1","question_id":"q1","status":"started"}}`,
+					},
+					{
+						channel: prefix + ":user:u1",
+						payload: `{"sequence":1,"session_id":"s1","event":"leaderboard.personalized","data":{"session_id":"s1","username":"u1","rank":1,"score":"2","gap":"0","top":[],"total":1}}`,
+					},
+				} {
+					require.NoError(t, a.redis.Publish(ctx, m.channel, m.payload).Err())
+				}
+			},
+			want: []want{
+				{sequence: 1, event: api.NotificationLeaderboardPersonalized},
+				{sequence: 2, event: domain.EventNameQuestionStarted},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{
+				Fanout:             api.FanoutUser,
+				LeaderboardUpdates: api.LeaderboardUpdates{Personalized: true},
+			})
+
+			stream, _ := a.watch(t, userContext(tt.user), &equizv1.WatchSessionRequest{SessionId: s1})
+			tt.publish(t, a)
+
+			for _, w := range tt.want {
+				resp := stream.next(t)
+				assert.Equal(t, w.sequence, resp.Sequence)
+				assert.Equal(t, w.event, resp.Event)
+			}
+		})
+	}
+}
+
+func TestAPI_WatchSession_Errors(t *testing.T) {
+	tests := map[string]struct {
+		ctx            context.Context
+		req            *equizv1.WatchSessionRequest
+		trim           bool
+		wantCode       errors.Code
+		wantReason     string
+		wantViolations []errors.PreconditionViolation
+	}{
+		"should fail if the notifications after the sequence are no longer retained": {
+			ctx:        userContext("u1"),
+			req:        &equizv1.WatchSessionRequest{SessionId: s1, FromSequence: 1},
+			trim:       true,
+			wantCode:   errors.CodeFailedPrecondition,
+			wantReason: errors.ReasonNotificationsTruncated,
+			wantViolations: []errors.PreconditionViolation{
+				{Type: "NOTIFICATION_RETENTION", Subject: s1, Description: "the oldest retained sequence is 3"},
+			},
+		},
+		"should deny the users not in the session": {
+			ctx:        userContext("u3"),
+			req:        &equizv1.WatchSessionRequest{SessionId: s1},
+			wantCode:   errors.CodePermissionDenied,
+			wantReason: errors.ReasonNotParticipant,
+		},
+		"should fail if the session doesn't exist": {
+			ctx:        userContext("u1"),
+			req:        &equizv1.WatchSessionRequest{SessionId: "s2"},
+			wantCode:   errors.CodeNotFound,
+			wantReason: errors.ReasonSessionNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{})
+			ctx := context.Background()
+
+			for _, q := range []string{"q1", "q2", "q3"} {
+				require.NoError(t, a.PublishQuestionStarted(ctx, domain.EventQuestionStarted{SessionID: s1, QuestionID: q}))
+			}
+
+			if tt.trim {
+				require.NoError(t, a.redis.XDel(ctx, prefix+":session:{s1}:notifications", "1-0", "2-0").Err())
+			}
+
+			_, errc := a.watch(t, tt.ctx, tt.req)
+
+			var err error
+			select {
+			case err = <-errc:
+			case <-time.After(time.Second):
+				t.Fatal("watch should fail")
+			}
+
+			e := errors.Convert(err)
+			assert.Equal(t, tt.wantCode, e.Code)
+			assert.Equal(t, tt.wantReason, e.Reason)
+			assert.Equal(t, tt.wantViolations, e.PreconditionViolations)
+		})
+	}
+}
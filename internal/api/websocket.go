@@ -0,0 +1,369 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	equizv1 "github.com/victornm/equiz/internal/api/proto/equiz/v1"
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/ratelimit"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 4 << 10
+	// wsSendBuffer is the number of messages buffered for a client before it is disconnected.
+	wsSendBuffer = 256
+)
+
+const (
+	WSMessageSubmitAnswer = "submit_answer"
+	WSMessageSubscribe    = "subscribe"
+
+	WSEventSubmitAnswerResult = "submit_answer.result"
+	WSEventSubscribeResult    = "subscribe.result"
+	WSEventError              = "error"
+)
+
+type (
+	// WSMessage is a message sent by a WebSocket client.
+	WSMessage struct {
+		// ID is echoed in the reply, so the client can correlate them.
+		ID   string          `json:"id,omitempty"`
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	WSSubmitAnswer struct {
+		SessionID  string `json:"session_id"`
+		QuestionID string `json:"question_id"`
+		Answer     string `json:"answer"`
+	}
+
+	WSSubmitAnswerResult struct {
+		ID         string  `json:"id,omitempty"`
+		Score      float64 `json:"score"`
+		TotalScore float64 `json:"total_score"`
+	}
+
+	// WSSubscribe subscribes a member of a session to its notifications.
+	WSSubscribe struct {
+		SessionID string `json:"session_id"`
+	}
+
+	WSSubscribeResult struct {
+		ID        string `json:"id,omitempty"`
+		SessionID string `json:"session_id"`
+	}
+
+	WSError struct {
+		ID string `json:"id,omitempty"`
+		*errors.Error
+	}
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1 << 10,
+	WriteBufferSize: 1 << 10,
+}
+
+// serveWebSocket forwards the notifications to the WebSocket connection, and handles the messages of the user.
+func (a *API) serveWebSocket(c *gin.Context) {
+	p, err := principal(c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	user := p.Username
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader already replied with an HTTP error.
+		slog.WarnContext(c, "api: websocket upgrade failed", "username", user, "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.Request.Context()))
+	defer cancel()
+
+	client := &wsClient{
+		user: user,
+		conn: conn,
+		send: make(chan []byte, wsSendBuffer),
+		done: make(chan struct{}),
+
+		personalized: map[string]bool{},
+		leaderboards: map[string]int64{},
+	}
+
+	if !a.addWebSocket(client) {
+		client.close(websocket.CloseGoingAway, "server is shutting down")
+		return
+	}
+	defer a.removeWebSocket(client)
+
+	sub := a.redis.Subscribe(ctx, a.userChannel(user))
+	defer sub.Close()
+	// The client is closed before the subscription, so closing the subscription isn't reported to the client.
+	defer client.close(websocket.CloseNormalClosure, "")
+
+	go client.writeLoop(ctx)
+	go func() {
+		ch := sub.Channel()
+		for {
+			select {
+			case <-client.done:
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					client.close(websocket.CloseInternalServerErr, "subscription closed")
+					return
+				}
+				if !a.isDuplicate(client, msg) {
+					client.enqueue(ctx, []byte(msg.Payload))
+				}
+			}
+		}
+	}()
+
+	client.readLoop(ctx, func(ctx context.Context, msg WSMessage) Notification {
+		return a.handleWSMessage(ctx, client, sub, msg)
+	})
+}
+
+// CloseWebSockets closes the hijacked WebSocket connections, and waits for their handlers to return.
+func (a *API) CloseWebSockets() {
+	a.ws.Lock()
+	a.ws.closed = true
+	for c := range a.ws.clients {
+		c.close(websocket.CloseGoingAway, "server is shutting down")
+	}
+	a.ws.Unlock()
+
+	a.ws.handlers.Wait()
+}
+
+// addWebSocket registers the client, it returns false if the WebSockets are closed.
+func (a *API) addWebSocket(c *wsClient) bool {
+	a.ws.Lock()
+	defer a.ws.Unlock()
+
+	if a.ws.closed {
+		return false
+	}
+
+	a.ws.clients[c] = struct{}{}
+	a.ws.handlers.Add(1)
+	return true
+}
+
+func (a *API) removeWebSocket(c *wsClient) {
+	a.ws.Lock()
+	defer a.ws.Unlock()
+
+	delete(a.ws.clients, c)
+	a.ws.handlers.Done()
+}
+
+// isDuplicate reports whether the message is a leaderboard update already forwarded from another channel.
+func (a *API) isDuplicate(c *wsClient, msg *redis.Message) bool {
+	if a.fanout != FanoutUser {
+		return false
+	}
+
+	n, err := decodeNotification(msg.Payload)
+	if err != nil || !isLeaderboardUpdate(n.Event) {
+		return false
+	}
+
+	// The personalized updates of the user channel replace the ones of the session channel.
+	if msg.Channel != a.userChannel(c.user) && c.isPersonalized(n.SessionID) {
+		return true
+	}
+
+	if n.Sequence <= c.leaderboards[n.SessionID] {
+		return true
+	}
+
+	c.leaderboards[n.SessionID] = n.Sequence
+	return false
+}
+
+func (a *API) handleWSMessage(ctx context.Context, c *wsClient, sub *redis.PubSub, msg WSMessage) Notification {
+	switch msg.Type {
+	case WSMessageSubscribe:
+		var req WSSubscribe
+		if err := json.Unmarshal(msg.Data, &req); err != nil || req.SessionID == "" {
+			return wsError(ctx, msg.ID, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("invalid subscribe: session_id is required")))
+		}
+
+		p, _ := auth.FromContext(ctx)
+		ss, err := a.authorizeMember(ctx, p, req.SessionID)
+		if err != nil {
+			return wsError(ctx, msg.ID, err)
+		}
+
+		// Only the participants receive personalized leaderboards.
+		c.setPersonalized(req.SessionID, a.leaderboardUpdates.Personalized && slices.Contains(ss.Participants, p.Username))
+
+		if err := sub.Subscribe(ctx, a.sessionChannel(req.SessionID)); err != nil {
+			return wsError(ctx, msg.ID, err)
+		}
+
+		return Notification{
+			Event: WSEventSubscribeResult,
+			Data:  WSSubscribeResult{ID: msg.ID, SessionID: req.SessionID},
+		}
+
+	case WSMessageSubmitAnswer:
+		var req WSSubmitAnswer
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			return wsError(ctx, msg.ID, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("invalid submit_answer: %v", err)))
+		}
+
+		p, _ := auth.FromContext(ctx)
+		if err := a.limiter.Allow(ctx, ratelimit.Key{Method: "SubmitAnswer", User: p.Username, Session: req.SessionID}); err != nil {
+			return wsError(ctx, msg.ID, err)
+		}
+
+		resp, err := a.SubmitAnswer(ctx, &equizv1.SubmitAnswerRequest{
+			RequestId:  msg.ID,
+			SessionId:  req.SessionID,
+			QuestionId: req.QuestionID,
+			Answer:     req.Answer,
+			SubmitTime: timestamppb.Now(),
+		})
+		if err != nil {
+			return wsError(ctx, msg.ID, err)
+		}
+
+		return Notification{
+			Event: WSEventSubmitAnswerResult,
+			Data: WSSubmitAnswerResult{
+				ID:         msg.ID,
+				Score:      resp.Score,
+				TotalScore: resp.TotalScore,
+			},
+		}
+
+	default:
+		return wsError(ctx, msg.ID, errors.New(errors.CodeInvalidArgument, errors.WithMessagef("unknown message type: %s", msg.Type)))
+	}
+}
+
+func wsError(ctx context.Context, id string, err error) Notification {
+	return Notification{
+		Event: WSEventError,
+		Data:  WSError{ID: id, Error: errors.Public(ctx, err, "method", "ws")},
+	}
+}
+
+type wsClient struct {
+	user string
+	conn *websocket.Conn
+	send chan []byte
+
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// leaderboards are the sequences of the last leaderboard updates forwarded by the sessions.
+	leaderboards map[string]int64
+
+	mu sync.Mutex
+	// personalized are the subscribed sessions whose leaderboard updates are replaced by the personalized ones.
+	personalized map[string]bool
+}
+
+func (c *wsClient) setPersonalized(session string, personalized bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.personalized[session] = personalized
+}
+
+func (c *wsClient) isPersonalized(session string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.personalized[session]
+}
+
+// enqueue queues a message to be sent, the client is disconnected if it can't keep up.
+func (c *wsClient) enqueue(ctx context.Context, b []byte) {
+	select {
+	case c.send <- b:
+	case <-c.done:
+	default:
+		slog.WarnContext(ctx, "api: websocket client is too slow, disconnecting", "username", c.user)
+		c.close(websocket.ClosePolicyViolation, "slow consumer")
+	}
+}
+
+func (c *wsClient) close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteWait))
+		_ = c.conn.Close()
+	})
+}
+
+func (c *wsClient) readLoop(ctx context.Context, handle func(ctx context.Context, msg WSMessage) Notification) {
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var msg WSMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				slog.WarnContext(ctx, "api: websocket read failed", "username", c.user, "error", err)
+			}
+			return
+		}
+
+		b, err := json.Marshal(handle(ctx, msg))
+		if err != nil {
+			slog.ErrorContext(ctx, "api: marshal websocket reply failed", "username", c.user, "error", err)
+			continue
+		}
+		c.enqueue(ctx, b)
+	}
+}
+
+func (c *wsClient) writeLoop(ctx context.Context) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-c.done:
+			return
+		case b := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			err = c.conn.WriteMessage(websocket.TextMessage, b)
+		case <-ticker.C:
+			err = c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+		}
+
+		if err != nil {
+			slog.WarnContext(ctx, "api: websocket write failed", "username", c.user, "error", err)
+			c.close(websocket.CloseGoingAway, "")
+			return
+		}
+	}
+}
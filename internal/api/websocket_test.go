@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestAPI_WebSocket_Subscribe(t *testing.T) {
+	tests := map[string]struct {
+		user       string
+		wantEvent  string
+		wantReason string
+	}{
+		"should subscribe the participants to the session": {
+			user:      "u1",
+			wantEvent: api.WSEventSubscribeResult,
+		},
+		"should subscribe the quiz master to the session": {
+			user:      "qm",
+			wantEvent: api.WSEventSubscribeResult,
+		},
+		"should deny the users not in the session": {
+			user:       "u3",
+			wantEvent:  api.WSEventError,
+			wantReason: errors.ReasonNotParticipant,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{Fanout: api.FanoutSession})
+			conn := a.dialWebSocket(t, tt.user)
+
+			require.NoError(t, conn.WriteJSON(api.WSMessage{ID: "m1", Type: api.WSMessageSubscribe, Data: json.RawMessage(`{"session_id":"s1"}`)}))
+
+			var reply struct {
+				Event string `json:"event"`
+				Data  struct {
+					ID     string `json:"id"`
+					Reason string `json:"reason"`
+				} `json:"data"`
+			}
+			require.NoError(t, conn.ReadJSON(&reply))
+			assert.Equal(t, tt.wantEvent, reply.Event)
+			assert.Equal(t, "m1", reply.Data.ID)
+			assert.Equal(t, tt.wantReason, reply.Data.Reason)
+
+			if tt.wantReason != "" {
+				return
+			}
+
+			// The subscription isn't confirmed to the client, wait for it before publishing.
+			require.Eventually(t, func() bool {
+				n, err := a.redis.PubSubNumSub(context.Background(), prefix+":session:s1").Result()
+				return err == nil && n[prefix+":session:s1"] > 0
+			}, time.Second, 10*time.Millisecond)
+
+			require.NoError(t, a.PublishSessionStarted(context.Background(), domain.EventSessionStarted{Session: domain.Session{SessionID: s1}}))
+
+			var n api.Notification
+			require.NoError(t, conn.ReadJSON(&n))
+			assert.Equal(t, domain.EventNameSessionStarted, n.Event)
+			assert.Equal(t, int64(1), n.Sequence)
+		})
+	}
+}
+
+func TestAPI_WebSocket_LeaderboardUpdated(t *testing.T) {
+	tests := map[string]struct {
+		updates   api.LeaderboardUpdates
+		wantEvent string
+	}{
+		"should forward the leaderboard updates once": {
+			wantEvent: domain.EventNameLeaderboardUpdated,
+		},
+		"should only forward the personalized leaderboard updates": {
+			updates:   api.LeaderboardUpdates{Personalized: true},
+			wantEvent: api.NotificationLeaderboardPersonalized,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := newTestAPI(t, api.Config{Fanout: api.FanoutUser, LeaderboardUpdates: tt.updates})
+			conn := a.dialWebSocket(t, "u1")
+
+			require.NoError(t, conn.WriteJSON(api.WSMessage{ID: "m1", Type: api.WSMessageSubscribe, Data: json.RawMessage(`{"session_id":"s1"}`)}))
+
+			var reply api.Notification
+			require.NoError(t, conn.ReadJSON(&reply))
+			require.Equal(t, api.WSEventSubscribeResult, reply.Event)
+
+			require.Eventually(t, func() bool {
+				n, err := a.redis.PubSubNumSub(context.Background(), prefix+":session:s1").Result()
+				return err == nil && n[prefix+":session:s1"] > 0
+			}, time.Second, 10*time.Millisecond)
+
+			for range 2 {
+				require.NoError(t, a.PublishLeaderboardUpdated(context.Background(), domain.EventLeaderboardUpdated{Leaderboard: domain.Leaderboard{
+					SessionID: s1,
+					Entries:   []domain.LeaderboardEntry{{Username: "u1", Score: 1}},
+				}}))
+			}
+
+			// Each update is received once, in the order of the sequences.
+			for _, seq := range []int64{1, 2} {
+				var n api.Notification
+				require.NoError(t, conn.ReadJSON(&n))
+				assert.Equal(t, tt.wantEvent, n.Event)
+				assert.Equal(t, seq, n.Sequence)
+			}
+
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+			var n api.Notification
+			require.Error(t, conn.ReadJSON(&n), "unexpected notification")
+		})
+	}
+}
+
+func TestAPI_WebSocket_Unauthenticated(t *testing.T) {
+	a := newTestAPI(t, api.Config{})
+	srv := httptest.NewServer(a.http)
+	t.Cleanup(srv.Close)
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// dialWebSocket connects to the WebSocket endpoint as the user.
+func (a testAPI) dialWebSocket(t *testing.T, user string) *websocket.Conn {
+	t.Helper()
+
+	srv := httptest.NewServer(a.http)
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", http.Header{
+		"Authorization": {"Bearer " + token(t, user)},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+	return conn
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+type Config struct {
+	// Secret is the HMAC secret of the HS256, HS384 and HS512 tokens.
+	Secret string
+	// JWKS is the path of the JSON Web Key Set file with the public keys of the asymmetrically signed tokens.
+	JWKS string
+	// Issuer and Audience are verified if they are not empty.
+	Issuer   string
+	Audience string
+}
+
+// RoleAdmin is the role of the users allowed to manage all sessions.
+const RoleAdmin = "admin"
+
+// Principal is the authenticated caller.
+type Principal struct {
+	// Username is the subject of the token.
+	Username string
+	Roles    []string
+}
+
+// HasRole reports whether the principal has any of the given roles.
+func (p Principal) HasRole(roles ...string) bool {
+	for _, r := range p.Roles {
+		for _, role := range roles {
+			if r == role {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of the context.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Authenticator verifies the signed tokens of the callers.
+type Authenticator struct {
+	secret  []byte
+	jwks    jose.JSONWebKeySet
+	methods []string
+	opts    []jwt.ParserOption
+}
+
+func NewAuthenticator(c Config) (*Authenticator, error) {
+	a := &Authenticator{}
+
+	if c.Secret != "" {
+		a.secret = []byte(c.Secret)
+		a.methods = append(a.methods, "HS256", "HS384", "HS512")
+	}
+
+	if c.JWKS != "" {
+		b, err := os.ReadFile(c.JWKS)
+		if err != nil {
+			return nil, fmt.Errorf("auth: read JWKS: %w", err)
+		}
+
+		if err := json.Unmarshal(b, &a.jwks); err != nil {
+			return nil, fmt.Errorf("auth: parse JWKS %s: %w", c.JWKS, err)
+		}
+
+		a.methods = append(a.methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA")
+	}
+
+	if len(a.methods) == 0 {
+		return nil, fmt.Errorf("auth: secret or JWKS is required")
+	}
+
+	a.opts = []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+	}
+
+	if c.Issuer != "" {
+		a.opts = append(a.opts, jwt.WithIssuer(c.Issuer))
+	}
+
+	if c.Audience != "" {
+		a.opts = append(a.opts, jwt.WithAudience(c.Audience))
+	}
+
+	return a, nil
+}
+
+// Authenticate verifies the token, and returns the principal it identifies.
+func (a *Authenticator) Authenticate(token string) (Principal, error) {
+	var c claims
+	if _, err := jwt.ParseWithClaims(token, &c, a.key, a.opts...); err != nil {
+		return Principal{}, errors.New(errors.CodeUnauthenticated,
+			errors.WithMessagef("invalid token"),
+			errors.WithReason(errors.ReasonTokenInvalid),
+			errors.WithCause(err),
+		)
+	}
+
+	if c.Subject == "" {
+		return Principal{}, errors.New(errors.CodeUnauthenticated,
+			errors.WithMessagef("invalid token: subject is required"),
+			errors.WithReason(errors.ReasonTokenInvalid),
+		)
+	}
+
+	return Principal{
+		Username: c.Subject,
+		Roles:    c.Roles,
+	}, nil
+}
+
+func (a *Authenticator) key(t *jwt.Token) (any, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		return a.secret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	keys := a.jwks.Key(kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown key: %s", kid)
+	}
+
+	return keys[0].Public().Key, nil
+}
@@ -0,0 +1,130 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	const secret = "secret"
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: key.Public(), KeyID: "k1", Algorithm: "ES256", Use: "sig"},
+	}})
+	require.NoError(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	a, err := auth.NewAuthenticator(auth.Config{
+		Secret:   secret,
+		JWKS:     jwksFile,
+		Issuer:   "equiz",
+		Audience: "equiz-api",
+	})
+	require.NoError(t, err)
+
+	claims := func(sub string, exp time.Duration) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   sub,
+			"iss":   "equiz",
+			"aud":   "equiz-api",
+			"exp":   time.Now().Add(exp).Unix(),
+			"roles": []string{"quiz_master"},
+		}
+	}
+
+	signHMAC := func(c jwt.MapClaims, secret string) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return s
+	}
+
+	signECDSA := func(c jwt.MapClaims, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, c)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		require.NoError(t, err)
+		return s
+	}
+
+	tests := map[string]struct {
+		token   string
+		want    auth.Principal
+		wantErr bool
+	}{
+		"HMAC": {
+			token: signHMAC(claims("u1", time.Minute), secret),
+			want:  auth.Principal{Username: "u1", Roles: []string{"quiz_master"}},
+		},
+		"JWKS": {
+			token: signECDSA(claims("u1", time.Minute), "k1"),
+			want:  auth.Principal{Username: "u1", Roles: []string{"quiz_master"}},
+		},
+		"wrong secret": {
+			token:   signHMAC(claims("u1", time.Minute), "wrong"),
+			wantErr: true,
+		},
+		"unknown key": {
+			token:   signECDSA(claims("u1", time.Minute), "k2"),
+			wantErr: true,
+		},
+		"expired": {
+			token:   signHMAC(claims("u1", -time.Minute), secret),
+			wantErr: true,
+		},
+		"wrong audience": {
+			token: signHMAC(func() jwt.MapClaims {
+				c := claims("u1", time.Minute)
+				c["aud"] = "other"
+				return c
+			}(), secret),
+			wantErr: true,
+		},
+		"no subject": {
+			token:   signHMAC(claims("", time.Minute), secret),
+			wantErr: true,
+		},
+		"unsigned": {
+			token: func() string {
+				s, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims("u1", time.Minute)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				require.NoError(t, err)
+				return s
+			}(),
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := a.Authenticate(tt.token)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, errors.CodeUnauthenticated, errors.Convert(err).Code)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
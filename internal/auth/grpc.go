@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// GRPCServerInterceptor authenticates the bearer tokens of the gRPC calls,
+// the principal is placed in the context of the handlers.
+// The health checking and reflection services are public.
+func GRPCServerInterceptor(a *Authenticator) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(selector.UnaryServerInterceptor(auth.UnaryServerInterceptor(a.authenticateGRPC), selector.MatchFunc(private)))
+}
+
+func GRPCServerStreamInterceptor(a *Authenticator) grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(selector.StreamServerInterceptor(auth.StreamServerInterceptor(a.authenticateGRPC), selector.MatchFunc(private)))
+}
+
+func (a *Authenticator) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	token, err := auth.AuthFromMD(ctx, "bearer")
+	if err != nil {
+		e := errors.Convert(err)
+		return nil, errors.New(e.Code, errors.WithMessagef("%s", e.Message), errors.WithReason(errors.ReasonTokenMissing))
+	}
+
+	p, err := a.Authenticate(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithPrincipal(ctx, p), nil
+}
+
+func private(_ context.Context, c interceptors.CallMeta) bool {
+	return c.Service != healthpb.Health_ServiceDesc.ServiceName && !strings.HasPrefix(c.Service, "grpc.reflection.")
+}
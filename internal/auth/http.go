@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// queryToken is the query of the token, and its value replacing the token in the URLs of the requests.
+const (
+	queryToken         = "access_token"
+	redactedQueryToken = "REDACTED"
+)
+
+// MiddlewareOption configures the Middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+type middlewareOptions struct {
+	queryToken bool
+}
+
+// WithQueryToken also accepts the token in the access_token query.
+// Browsers can't set the headers of WebSocket and Server-Sent Events requests, so it is only for their endpoints.
+// The token is redacted from the URL of the request, so it isn't logged.
+func WithQueryToken() MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.queryToken = true
+	}
+}
+
+// Middleware authenticates the bearer tokens of the HTTP requests,
+// the principal is placed in the context of the request.
+func Middleware(a *Authenticator, opts ...MiddlewareOption) gin.HandlerFunc {
+	var o middlewareOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *gin.Context) {
+		var token string
+		if o.queryToken {
+			token = redactQueryToken(c.Request)
+		}
+
+		if h := c.GetHeader("Authorization"); h != "" {
+			scheme, t, ok := strings.Cut(h, " ")
+			if !ok || !strings.EqualFold(scheme, "bearer") {
+				abort(c, errors.New(errors.CodeUnauthenticated, errors.WithMessagef("bad authorization scheme"), errors.WithReason(errors.ReasonTokenMissing)))
+				return
+			}
+			token = t
+		}
+
+		if token == "" {
+			abort(c, errors.New(errors.CodeUnauthenticated, errors.WithMessagef("request unauthenticated with bearer"), errors.WithReason(errors.ReasonTokenMissing)))
+			return
+		}
+
+		p, err := a.Authenticate(token)
+		if err != nil {
+			abort(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(WithPrincipal(c.Request.Context(), p))
+		c.Next()
+	}
+}
+
+// redactQueryToken returns the token of the query, and replaces it in the URL of the request.
+func redactQueryToken(r *http.Request) string {
+	q := r.URL.Query()
+
+	token := q.Get(queryToken)
+	if token == "" {
+		return ""
+	}
+
+	q.Set(queryToken, redactedQueryToken)
+	r.URL.RawQuery = q.Encode()
+	r.RequestURI = r.URL.RequestURI()
+
+	return token
+}
+
+func abort(c *gin.Context, err error) {
+	e := errors.Public(c.Request.Context(), err, "method", c.Request.Method, "path", c.FullPath())
+	c.AbortWithStatusJSON(e.HTTPStatusCode(), e)
+}
@@ -0,0 +1,81 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/auth"
+)
+
+func TestMiddleware(t *testing.T) {
+	const secret = "secret"
+
+	a, err := auth.NewAuthenticator(auth.Config{Secret: secret})
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "u1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		opts       []auth.MiddlewareOption
+		header     string
+		query      string
+		wantStatus int
+		wantURI    string
+	}{
+		"should authenticate the bearer token of the header": {
+			header:     "Bearer " + token,
+			wantStatus: http.StatusOK,
+			wantURI:    "/",
+		},
+		"should reject the token of the query by default": {
+			query:      "?access_token=" + token,
+			wantStatus: http.StatusUnauthorized,
+		},
+		"should authenticate the token of the query and redact it": {
+			opts:       []auth.MiddlewareOption{auth.WithQueryToken()},
+			query:      "?access_token=" + token + "&from_sequence=1",
+			wantStatus: http.StatusOK,
+			wantURI:    "/?access_token=REDACTED&from_sequence=1",
+		},
+		"should reject the other schemes": {
+			header:     "Basic dTE6cA==",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var uri string
+			e := gin.New()
+			e.GET("/", auth.Middleware(a, tt.opts...), func(c *gin.Context) {
+				p, _ := auth.FromContext(c.Request.Context())
+				assert.Equal(t, "u1", p.Username)
+				uri = c.Request.RequestURI
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantURI, uri)
+		})
+	}
+}
@@ -2,34 +2,180 @@ package config
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
-// Load config from file into the config struct, config must be a pointer to the config struct
+// Validator is implemented by the configs which validate themselves after loading.
+type Validator interface {
+	Validate() error
+}
+
+// secretKeys are the keys of the secret values, they are redacted when printing the config.
+var secretKeys = []string{"pass", "password", "secret", "token"}
+
+const redacted = "REDACTED"
+
+// fileSuffix is the suffix of the keys whose values are read from files, e.g. Docker and Kubernetes secrets.
+const fileSuffix = "_file"
+
+// Load config from file into the config struct, config must be a pointer to the config struct.
+// The values of the config struct are the defaults, they are overridden by the file, then by the environment variables.
+// The value of a key can also be read from the file at the <key>_file key or <KEY>_FILE environment variable,
+// which overrides the value of the key.
+// Unknown keys in the file are errors, and the config is validated if it implements Validator.
 func Load(file string, config any) error {
+	_, err := load(file, config)
+	return err
+}
+
+// Print loads the config like Load, then writes the effective config as YAML, with the secrets redacted.
+func Print(w io.Writer, file string, config any) error {
+	settings, err := load(file, config)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(redact(settings)); err != nil {
+		return fmt.Errorf("encode config: %v", err)
+	}
+
+	return enc.Close()
+}
+
+// load loads the config, and returns the effective settings.
+func load(file string, config any) (map[string]any, error) {
 	v := viper.New()
 	m := make(map[string]any)
 
 	if err := mapstructure.Decode(config, &m); err != nil {
-		return fmt.Errorf("mapstructure: %v", err)
+		return nil, fmt.Errorf("mapstructure: %v", err)
 	}
 
 	if err := v.MergeConfigMap(m); err != nil {
-		return fmt.Errorf("merge config map: %v", err)
+		return nil, fmt.Errorf("merge config map: %v", err)
 	}
 
 	v.SetConfigFile(file)
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
-	if err := v.ReadInConfig(); err != nil {
-		return fmt.Errorf("read config from file %s: %v", file, err)
+	if err := v.MergeInConfig(); err != nil {
+		return nil, fmt.Errorf("read config from file %s: %v", file, err)
+	}
+
+	settings := v.AllSettings()
+	if err := readFiles(settings, ""); err != nil {
+		return nil, err
+	}
+
+	// Like viper.UnmarshalExact, but from the settings with the values of the files.
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           config,
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mapstructure: %v", err)
 	}
-	if err := v.Unmarshal(config); err != nil {
-		return fmt.Errorf("unmarshal config: %v", err)
+	if err := dec.Decode(settings); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %v", err)
+	}
+
+	if c, ok := config.(Validator); ok {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	return settings, nil
+}
+
+// readFiles replaces the values of the keys with the content of the files at their <key>_file keys,
+// or <KEY>_FILE environment variables, then removes the <key>_file keys.
+// The trailing newlines of the files are trimmed.
+func readFiles(settings map[string]any, prefix string) error {
+	for k, v := range settings {
+		switch v := v.(type) {
+		case map[string]any:
+			if err := readFiles(v, prefix+k+"."); err != nil {
+				return err
+			}
+		case []any:
+			for i, e := range v {
+				if m, ok := e.(map[string]any); ok {
+					if err := readFiles(m, fmt.Sprintf("%s%s.%d.", prefix, k, i)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	keys := make(map[string]string)
+	for k, v := range settings {
+		if key, ok := strings.CutSuffix(k, fileSuffix); ok {
+			if s, ok := v.(string); ok && s != "" {
+				keys[key] = s
+			}
+			delete(settings, k)
+			continue
+		}
+
+		env := strings.ToUpper(strings.ReplaceAll(prefix+k, ".", "_") + fileSuffix)
+		if s := os.Getenv(env); s != "" {
+			keys[k] = s
+		}
+	}
+
+	for key, file := range keys {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read %s%s from file: %v", prefix, key, err)
+		}
+		settings[key] = strings.TrimRight(string(b), "\r\n")
 	}
 
 	return nil
 }
+
+// redact replaces the non-empty secret values in the settings,
+// and formats the durations of the defaults like the ones in the files.
+func redact(settings any) any {
+	switch s := settings.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(s))
+		for k, v := range s {
+			if slices.Contains(secretKeys, strings.ToLower(k)) && v != "" && v != nil {
+				res[k] = redacted
+				continue
+			}
+			res[k] = redact(v)
+		}
+		return res
+
+	case []any:
+		res := make([]any, 0, len(s))
+		for _, v := range s {
+			res = append(res, redact(v))
+		}
+		return res
+
+	case time.Duration:
+		return s.String()
+	}
+
+	return settings
+}
@@ -0,0 +1,254 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/config"
+)
+
+type testConfig struct {
+	Port    int32
+	Timeout time.Duration
+	DB      struct {
+		Addr string
+		Pass string
+	}
+	Limits map[string]int
+}
+
+func (c *testConfig) Validate() error {
+	if c.Port <= 0 {
+		return stderrors.New("port: is required")
+	}
+	return nil
+}
+
+func defaultConfig() testConfig {
+	c := testConfig{Port: 8080, Timeout: time.Second}
+	c.DB.Addr = "localhost:5432"
+	return c
+}
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		file    string
+		env     map[string]string
+		want    func(c *testConfig)
+		wantErr string
+	}{
+		"defaults": {
+			file: "db:\n  pass: secret\n",
+			want: func(c *testConfig) {
+				c.DB.Pass = "secret"
+			},
+		},
+		"override defaults": {
+			file: "port: 9090\ntimeout: 5s\ndb:\n  addr: db:5432\nlimits:\n  SubmitAnswer: 5\n",
+			want: func(c *testConfig) {
+				c.Port = 9090
+				c.Timeout = 5 * time.Second
+				c.DB.Addr = "db:5432"
+				c.Limits = map[string]int{"submitanswer": 5}
+			},
+		},
+		"override by environment variables": {
+			file: "port: 9090\n",
+			env:  map[string]string{"PORT": "7070", "DB_PASS": "env-secret"},
+			want: func(c *testConfig) {
+				c.Port = 7070
+				c.DB.Pass = "env-secret"
+			},
+		},
+		"read from files": {
+			file: "db:\n  pass: plain\n  pass_file: " + writeFile(t, "file-secret\n") + "\n",
+			want: func(c *testConfig) {
+				c.DB.Pass = "file-secret"
+			},
+		},
+		"read from files of environment variables": {
+			file: "db:\n  pass: plain\n",
+			env:  map[string]string{"DB_PASS_FILE": writeFile(t, "env-file-secret")},
+			want: func(c *testConfig) {
+				c.DB.Pass = "env-file-secret"
+			},
+		},
+		"missing file": {
+			file:    "db:\n  pass_file: /not/found\n",
+			wantErr: "read db.pass from file",
+		},
+		"unknown file key": {
+			file:    "db:\n  password_file: " + writeFile(t, "secret") + "\n",
+			wantErr: "invalid keys: password",
+		},
+		"unknown key": {
+			file:    "port: 9090\ndb:\n  adr: db:5432\n",
+			wantErr: "invalid keys: adr",
+		},
+		"invalid": {
+			file:    "port: 0\n",
+			wantErr: "port: is required",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			c := defaultConfig()
+			err := config.Load(writeFile(t, tt.file), &c)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			want := defaultConfig()
+			tt.want(&want)
+			require.Equal(t, want, c)
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	c := defaultConfig()
+
+	var b bytes.Buffer
+	require.NoError(t, config.Print(&b, writeFile(t, "db:\n  pass: secret\n"), &c))
+
+	require.Equal(t, `db:
+  addr: localhost:5432
+  pass: REDACTED
+limits: {}
+port: 8080
+timeout: 1s
+`, b.String())
+}
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		change func(c *testConfig)
+		want   []string
+	}{
+		"equal": {
+			change: func(c *testConfig) {},
+		},
+		"fields": {
+			change: func(c *testConfig) {
+				c.Port = 9090
+				c.DB.Pass = "secret"
+			},
+			want: []string{"db.pass", "port"},
+		},
+		"map values": {
+			change: func(c *testConfig) {
+				c.Limits["a"] = 2
+				delete(c.Limits, "b")
+				c.Limits["c"] = 3
+			},
+			want: []string{"limits.a", "limits.b", "limits.c"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := defaultConfig(), defaultConfig()
+			a.Limits = map[string]int{"a": 1, "b": 1}
+			b.Limits = map[string]int{"a": 1, "b": 1}
+			tt.change(&b)
+
+			require.Equal(t, tt.want, config.Diff(a, b))
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	f := writeFile(t, "port: 8080\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		config.Watch(ctx, f, func() {
+			reloaded <- struct{}{}
+		})
+	}()
+
+	// Wait for the watcher to be added.
+	time.Sleep(100 * time.Millisecond)
+
+	// The writes within the debounce interval are one reload.
+	require.NoError(t, os.WriteFile(f, []byte("port: 9090\n"), 0o600))
+	require.NoError(t, os.WriteFile(f, []byte("port: 9091\n"), 0o600))
+
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("not reloaded")
+	}
+
+	select {
+	case <-reloaded:
+		t.Fatal("reloaded twice")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatch_SIGHUP(t *testing.T) {
+	// The directory doesn't exist, so only SIGHUP reloads.
+	f := filepath.Join(t.TempDir(), "missing", "config.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		config.Watch(ctx, f, func() {
+			reloaded <- struct{}{}
+		})
+	}()
+
+	// Wait for the signal to be registered.
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("not reloaded")
+	}
+
+	cancel()
+	<-done
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	f := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(f, []byte(content), 0o600))
+
+	return f
+}
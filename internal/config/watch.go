@@ -0,0 +1,161 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the events of a file change, editors usually write a file in several events.
+const watchDebounce = 100 * time.Millisecond
+
+// Watch calls reload when the file changes or the process receives SIGHUP, until the context is done.
+// The directory of the file is watched, so the file can be replaced, e.g. by the editors writing a new file
+// and renaming it, or by Kubernetes swapping the symlinks of the ConfigMap volumes.
+// If the file can't be watched, it still reloads on SIGHUP.
+func Watch(ctx context.Context, file string, reload func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	// The channels of the watcher are nil if it fails, so only SIGHUP is received.
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+	if w, err := watchDir(file); err != nil {
+		slog.Error("config: watch failed, reloading on SIGHUP only", "file", file, "error", err)
+	} else {
+		defer w.Close()
+		events, errs = w.Events, w.Errors
+	}
+
+	name := filepath.Clean(file)
+	target, _ := filepath.EvalSymlinks(file)
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-hup:
+			slog.Info("config: SIGHUP received", "file", file)
+			reload()
+
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// The events of the symlinks are of their names, so the change of the target is checked instead.
+			t, _ := filepath.EvalSymlinks(file)
+			if (filepath.Clean(e.Name) == name && e.Has(fsnotify.Write|fsnotify.Create)) || (t != "" && t != target) {
+				target = t
+				debounce.Reset(watchDebounce)
+			}
+
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			slog.Error("config: watch failed", "file", file, "error", err)
+
+		case <-debounce.C:
+			slog.Info("config: file changed", "file", file)
+			reload()
+		}
+	}
+}
+
+// watchDir watches the directory of the file.
+func watchDir(file string) (*fsnotify.Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("new watcher: %v", err)
+	}
+
+	if err := w.Add(filepath.Dir(file)); err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("watch %s: %v", file, err)
+	}
+
+	return w, nil
+}
+
+// Diff returns the sorted keys of the values which differ between the configs of the same type.
+// The keys are lowercased like the keys of the files, and the values of the maps are compared by their keys,
+// e.g. ratelimit.methods.submitanswer.
+func Diff(a, b any) []string {
+	var keys []string
+	diff(reflect.ValueOf(a), reflect.ValueOf(b), "", &keys)
+	slices.Sort(keys)
+
+	return keys
+}
+
+func diff(a, b reflect.Value, key string, keys *[]string) {
+	join := func(k string) string {
+		if key == "" {
+			return k
+		}
+		return key + "." + k
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				*keys = append(*keys, key)
+			}
+			return
+		}
+		diff(a.Elem(), b.Elem(), key, keys)
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			// The fields of the embedded structs are squashed, like in the files.
+			if f.Anonymous {
+				diff(a.Field(i), b.Field(i), key, keys)
+				continue
+			}
+			diff(a.Field(i), b.Field(i), join(strings.ToLower(f.Name)), keys)
+		}
+
+	case reflect.Map:
+		for _, k := range a.MapKeys() {
+			if v := b.MapIndex(k); v.IsValid() {
+				diff(a.MapIndex(k), v, join(fmt.Sprint(k)), keys)
+			} else {
+				*keys = append(*keys, join(fmt.Sprint(k)))
+			}
+		}
+		for _, k := range b.MapKeys() {
+			if !a.MapIndex(k).IsValid() {
+				*keys = append(*keys, join(fmt.Sprint(k)))
+			}
+		}
+
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*keys = append(*keys, key)
+		}
+	}
+}
@@ -1,17 +1,40 @@
 package domain
 
 const (
+	EventNameSessionStarted     = "session.started"
 	EventNameSessionEnded       = "session.ended"
+	EventNameQuestionStarted    = "question.started"
+	EventNameQuestionEnded      = "question.ended"
 	EventNameScoreUpdated       = "score.updated"
 	EventNameLeaderboardUpdated = "leaderboard.updated"
 )
 
+type EventSessionStarted struct {
+	Session Session
+}
+
+func (EventSessionStarted) Name() string { return EventNameSessionStarted }
+
 type EventSessionEnded struct {
 	Session Session
 }
 
 func (EventSessionEnded) Name() string { return EventNameSessionEnded }
 
+type EventQuestionStarted struct {
+	SessionID  string
+	QuestionID string
+}
+
+func (EventQuestionStarted) Name() string { return EventNameQuestionStarted }
+
+type EventQuestionEnded struct {
+	SessionID  string
+	QuestionID string
+}
+
+func (EventQuestionEnded) Name() string { return EventNameQuestionEnded }
+
 type EventScoreUpdated struct {
 	Score Score
 }
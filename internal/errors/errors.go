@@ -1,36 +1,89 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type Code codes.Code
 
 const (
-	CodeInvalidArgument = Code(codes.InvalidArgument)
-	CodeNotFound        = Code(codes.NotFound)
-	CodeAlreadyExists   = Code(codes.AlreadyExists)
-	CodeInternal        = Code(codes.Internal)
-	CodeUnauthenticated = Code(codes.Unauthenticated)
+	CodeCanceled           = Code(codes.Canceled)
+	CodeInvalidArgument    = Code(codes.InvalidArgument)
+	CodeDeadlineExceeded   = Code(codes.DeadlineExceeded)
+	CodeNotFound           = Code(codes.NotFound)
+	CodeAlreadyExists      = Code(codes.AlreadyExists)
+	CodePermissionDenied   = Code(codes.PermissionDenied)
+	CodeResourceExhausted  = Code(codes.ResourceExhausted)
+	CodeFailedPrecondition = Code(codes.FailedPrecondition)
+	CodeAborted            = Code(codes.Aborted)
+	CodeUnimplemented      = Code(codes.Unimplemented)
+	CodeInternal           = Code(codes.Internal)
+	CodeUnavailable        = Code(codes.Unavailable)
+	CodeUnauthenticated    = Code(codes.Unauthenticated)
 )
 
+// statusClientClosedRequest is the non-standard status code of the requests canceled by the clients.
+const statusClientClosedRequest = 499
+
 var code2http = map[Code]int{
-	CodeInvalidArgument: http.StatusBadRequest,
-	CodeNotFound:        http.StatusNotFound,
-	CodeAlreadyExists:   http.StatusConflict,
-	CodeInternal:        http.StatusInternalServerError,
-	CodeUnauthenticated: http.StatusUnauthorized,
+	CodeCanceled:           statusClientClosedRequest,
+	CodeInvalidArgument:    http.StatusBadRequest,
+	CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	CodeNotFound:           http.StatusNotFound,
+	CodeAlreadyExists:      http.StatusConflict,
+	CodePermissionDenied:   http.StatusForbidden,
+	CodeResourceExhausted:  http.StatusTooManyRequests,
+	CodeFailedPrecondition: http.StatusBadRequest,
+	CodeAborted:            http.StatusConflict,
+	CodeUnimplemented:      http.StatusNotImplemented,
+	CodeInternal:           http.StatusInternalServerError,
+	CodeUnavailable:        http.StatusServiceUnavailable,
+	CodeUnauthenticated:    http.StatusUnauthorized,
 }
 
+// Domain is the domain of the error reasons, see errdetails.ErrorInfo.
+const Domain = "equiz"
+
 type Error struct {
 	Code    Code   `json:"code"`
 	Message string `json:"message"`
-	err     error
+	// Reason is a stable identifier of the cause of the error, e.g. SESSION_NOT_FOUND.
+	Reason string `json:"reason,omitempty"`
+	// Metadata is the additional information about the reason, e.g. the session ID.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FieldViolations are the invalid fields of the request.
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+	// PreconditionViolations are the failed preconditions of the request.
+	PreconditionViolations []PreconditionViolation `json:"precondition_violations,omitempty"`
+	// RetryAfter is the time the client should wait before retrying, in seconds.
+	RetryAfter float64 `json:"retry_after,omitempty"`
+	// LocalizedMessage is the message for the users, Message is for the developers.
+	LocalizedMessage *LocalizedMessage `json:"localized_message,omitempty"`
+	err              error
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type PreconditionViolation struct {
+	// Type is the type of the precondition, e.g. SESSION_STATE.
+	Type string `json:"type"`
+	// Subject is what failed the precondition, e.g. the session ID.
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
 }
 
 func New(code Code, opts ...Option) *Error {
@@ -59,8 +112,63 @@ func (e *Error) Unwrap() error {
 	return e.err
 }
 
+// GRPCStatus returns the status of the error, with the standard details of the reason, violations and retry delay.
 func (e *Error) GRPCStatus() *status.Status {
-	return status.New(codes.Code(e.Code), e.Message)
+	st := status.New(codes.Code(e.Code), e.Message)
+
+	var details []protoadapt.MessageV1
+	if e.Reason != "" {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   e.Reason,
+			Domain:   Domain,
+			Metadata: e.Metadata,
+		})
+	}
+
+	if len(e.FieldViolations) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, v := range e.FieldViolations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, br)
+	}
+
+	if len(e.PreconditionViolations) > 0 {
+		pf := &errdetails.PreconditionFailure{}
+		for _, v := range e.PreconditionViolations {
+			pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        v.Type,
+				Subject:     v.Subject,
+				Description: v.Description,
+			})
+		}
+		details = append(details, pf)
+	}
+
+	if e.RetryAfter > 0 {
+		delay := time.Duration(e.RetryAfter * float64(time.Second))
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	}
+
+	if e.LocalizedMessage != nil {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  e.LocalizedMessage.Locale,
+			Message: e.LocalizedMessage.Message,
+		})
+	}
+
+	if len(details) == 0 {
+		return st
+	}
+
+	if d, err := st.WithDetails(details...); err == nil {
+		return d
+	}
+
+	return st
 }
 
 func (e *Error) HTTPStatusCode() int {
@@ -71,10 +179,62 @@ func (e *Error) HTTPStatusCode() int {
 	return http.StatusInternalServerError
 }
 
+// Convert converts err to an *Error, gRPC status errors keep their code, message and details.
+// The context errors are converted to DeadlineExceeded and Canceled, other errors are converted to internal errors.
 func Convert(err error) *Error {
 	var e *Error
-	if !errors.As(err, &e) {
-		return Internal(err)
+	if errors.As(err, &e) {
+		return e
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return fromStatus(st, err)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return New(CodeDeadlineExceeded, WithCause(err))
+	case errors.Is(err, context.Canceled):
+		return New(CodeCanceled, WithCause(err))
+	}
+
+	return Internal(err)
+}
+
+// Public converts err to an *Error which is safe to return to the clients,
+// with the message for the users in the locale of the context.
+// The internal errors are logged with the context and the key-value pairs,
+// then replaced by a generic error, so their messages and causes aren't leaked.
+func Public(ctx context.Context, err error, keyvals ...any) *Error {
+	e := Convert(err)
+	if e.Code == CodeInternal {
+		slog.ErrorContext(ctx, "errors: internal error", append(keyvals, "error", err)...)
+		e = New(CodeInternal)
+	}
+
+	return e.Localize(Locale(ctx))
+}
+
+func fromStatus(st *status.Status, err error) *Error {
+	e := New(Code(st.Code()), WithMessagef("%s", st.Message()), WithCause(err))
+
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			e.Reason, e.Metadata = d.Reason, d.Metadata
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				WithFieldViolation(v.Field, v.Description).apply(e)
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.Violations {
+				WithPreconditionViolation(v.Type, v.Subject, v.Description).apply(e)
+			}
+		case *errdetails.RetryInfo:
+			WithRetryAfter(d.RetryDelay.AsDuration()).apply(e)
+		case *errdetails.LocalizedMessage:
+			e.LocalizedMessage = &LocalizedMessage{Locale: d.Locale, Message: d.Message}
+		}
 	}
 
 	return e
@@ -84,6 +244,16 @@ func Internal(err error) *Error {
 	return New(CodeInternal, WithCause(err))
 }
 
+// InvalidField returns an InvalidArgument error of an invalid field of the request.
+func InvalidField(field, format string, args ...any) *Error {
+	description := fmt.Sprintf(format, args...)
+
+	return New(CodeInvalidArgument,
+		WithMessagef("invalid %s: %s", field, description),
+		WithFieldViolation(field, description),
+	)
+}
+
 type Option interface {
 	apply(*Error)
 }
@@ -100,6 +270,45 @@ func WithCause(err error) Option {
 	})
 }
 
+// WithRetryAfter tells the client to wait for d before retrying.
+func WithRetryAfter(d time.Duration) Option {
+	return optionFunc(func(e *Error) {
+		e.RetryAfter = d.Seconds()
+	})
+}
+
+// WithReason sets the stable reason of the error, with the metadata given as key-value pairs.
+func WithReason(reason string, keyvals ...string) Option {
+	return optionFunc(func(e *Error) {
+		e.Reason = reason
+
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			if e.Metadata == nil {
+				e.Metadata = make(map[string]string, len(keyvals)/2)
+			}
+			e.Metadata[keyvals[i]] = keyvals[i+1]
+		}
+	})
+}
+
+// WithFieldViolation adds an invalid field of the request.
+func WithFieldViolation(field, description string) Option {
+	return optionFunc(func(e *Error) {
+		e.FieldViolations = append(e.FieldViolations, FieldViolation{Field: field, Description: description})
+	})
+}
+
+// WithPreconditionViolation adds a failed precondition of the request.
+func WithPreconditionViolation(typ, subject, description string) Option {
+	return optionFunc(func(e *Error) {
+		e.PreconditionViolations = append(e.PreconditionViolations, PreconditionViolation{
+			Type:        typ,
+			Subject:     subject,
+			Description: description,
+		})
+	})
+}
+
 func WithMessagef(format string, args ...any) Option {
 	return optionFunc(func(e *Error) {
 		e.Message = fmt.Sprintf(format, args...)
@@ -0,0 +1,221 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+func TestError_Details(t *testing.T) {
+	tests := map[string]struct {
+		err     *errors.Error
+		details []proto.Message
+		json    string
+	}{
+		"no details": {
+			err:  errors.New(errors.CodeNotFound),
+			json: `{"code":5,"message":"NotFound"}`,
+		},
+		"error info": {
+			err: errors.New(errors.CodeNotFound,
+				errors.WithMessagef("session not found"),
+				errors.WithReason(errors.ReasonSessionNotFound, "session_id", "s1"),
+			),
+			details: []proto.Message{
+				&errdetails.ErrorInfo{Reason: errors.ReasonSessionNotFound, Domain: errors.Domain, Metadata: map[string]string{"session_id": "s1"}},
+			},
+			json: `{"code":5,"message":"session not found","reason":"SESSION_NOT_FOUND","metadata":{"session_id":"s1"}}`,
+		},
+		"field violations": {
+			err: errors.InvalidField("session_id", "is required"),
+			details: []proto.Message{
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "session_id", Description: "is required"}}},
+			},
+			json: `{"code":3,"message":"invalid session_id: is required","field_violations":[{"field":"session_id","description":"is required"}]}`,
+		},
+		"precondition failure and retry": {
+			err: errors.New(errors.CodeResourceExhausted,
+				errors.WithMessagef("slow down"),
+				errors.WithPreconditionViolation("SESSION_STATE", "s1", "the session is ended"),
+				errors.WithRetryAfter(1500*time.Millisecond),
+			),
+			details: []proto.Message{
+				&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{Type: "SESSION_STATE", Subject: "s1", Description: "the session is ended"}}},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)},
+			},
+			json: `{"code":8,"message":"slow down","precondition_violations":[{"type":"SESSION_STATE","subject":"s1","description":"the session is ended"}],"retry_after":1.5}`,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			st := tt.err.GRPCStatus()
+			require.Equal(t, codes.Code(tt.err.Code), st.Code())
+			require.Equal(t, tt.err.Message, st.Message())
+
+			details := st.Details()
+			require.Len(t, details, len(tt.details))
+			for i, d := range details {
+				require.True(t, proto.Equal(tt.details[i], d.(proto.Message)), "detail %d: %v", i, d)
+			}
+
+			b, err := json.Marshal(tt.err)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(b))
+
+			// The details survive a round trip through the gRPC status.
+			converted := errors.Convert(st.Err())
+			b, err = json.Marshal(converted)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(b))
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		code       errors.Code
+		httpStatus int
+	}{
+		"error": {
+			err:        errors.New(errors.CodeFailedPrecondition),
+			code:       errors.CodeFailedPrecondition,
+			httpStatus: http.StatusBadRequest,
+		},
+		"wrapped error": {
+			err:        fmt.Errorf("wrap: %w", errors.New(errors.CodeAborted)),
+			code:       errors.CodeAborted,
+			httpStatus: http.StatusConflict,
+		},
+		"status": {
+			err:        status.Error(codes.Unavailable, "unavailable"),
+			code:       errors.CodeUnavailable,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		"unknown status": {
+			err:        status.Error(codes.Unknown, "unknown"),
+			code:       errors.CodeInternal,
+			httpStatus: http.StatusInternalServerError,
+		},
+		"deadline exceeded": {
+			err:        fmt.Errorf("query: %w", context.DeadlineExceeded),
+			code:       errors.CodeDeadlineExceeded,
+			httpStatus: http.StatusGatewayTimeout,
+		},
+		"canceled": {
+			err:        context.Canceled,
+			code:       errors.CodeCanceled,
+			httpStatus: 499,
+		},
+		"other": {
+			err:        fmt.Errorf("connect: connection refused"),
+			code:       errors.CodeInternal,
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := errors.Convert(tt.err)
+			require.Equal(t, tt.code, e.Code)
+			require.Equal(t, tt.httpStatus, e.HTTPStatusCode())
+		})
+	}
+}
+
+func TestPublic(t *testing.T) {
+	tests := map[string]struct {
+		ctx  context.Context
+		err  error
+		want *errors.Error
+	}{
+		"hide internal causes": {
+			ctx: context.Background(),
+			err: fmt.Errorf("insert session: %w", fmt.Errorf("password authentication failed")),
+			want: &errors.Error{
+				Code:             errors.CodeInternal,
+				Message:          "Internal",
+				LocalizedMessage: &errors.LocalizedMessage{Locale: "en", Message: "Something went wrong. Please try again later."},
+			},
+		},
+		"hide internal messages": {
+			ctx: context.Background(),
+			err: errors.New(errors.CodeInternal, errors.WithMessagef("select session: timeout")),
+			want: &errors.Error{
+				Code:             errors.CodeInternal,
+				Message:          "Internal",
+				LocalizedMessage: &errors.LocalizedMessage{Locale: "en", Message: "Something went wrong. Please try again later."},
+			},
+		},
+		"localize by reason": {
+			ctx: errors.WithLocale(context.Background(), errors.NegotiateLocale("fr-CH, vi;q=0.9, en;q=0.8")),
+			err: fmt.Errorf("get session: %w", errors.New(errors.CodeNotFound,
+				errors.WithMessagef("session not found: session=s1"),
+				errors.WithReason(errors.ReasonSessionNotFound),
+			)),
+			want: &errors.Error{
+				Code:             errors.CodeNotFound,
+				Message:          "session not found: session=s1",
+				Reason:           errors.ReasonSessionNotFound,
+				LocalizedMessage: &errors.LocalizedMessage{Locale: "vi", Message: "Phiên thi không tồn tại."},
+			},
+		},
+		"localize by code": {
+			ctx: errors.WithLocale(context.Background(), errors.NegotiateLocale("vi-VN")),
+			err: errors.InvalidField("session_id", "is required"),
+			want: &errors.Error{
+				Code:             errors.CodeInvalidArgument,
+				Message:          "invalid session_id: is required",
+				FieldViolations:  []errors.FieldViolation{{Field: "session_id", Description: "is required"}},
+				LocalizedMessage: &errors.LocalizedMessage{Locale: "vi", Message: "Yêu cầu không hợp lệ."},
+			},
+		},
+		"default locale": {
+			ctx: errors.WithLocale(context.Background(), errors.NegotiateLocale("fr", "invalid;;")),
+			err: errors.New(errors.CodeUnauthenticated, errors.WithReason(errors.ReasonTokenMissing)),
+			want: &errors.Error{
+				Code:             errors.CodeUnauthenticated,
+				Message:          "Unauthenticated",
+				Reason:           errors.ReasonTokenMissing,
+				LocalizedMessage: &errors.LocalizedMessage{Locale: "en", Message: "Please sign in to continue."},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := errors.Public(tt.ctx, tt.err)
+
+			b, err := json.Marshal(got)
+			require.NoError(t, err)
+			want, err := json.Marshal(tt.want)
+			require.NoError(t, err)
+			require.JSONEq(t, string(want), string(b))
+
+			// The localized message is also a detail of the gRPC status.
+			require.Equal(t, tt.want.LocalizedMessage, errors.Convert(got.GRPCStatus().Err()).LocalizedMessage)
+		})
+	}
+}
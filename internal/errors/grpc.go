@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCServerInterceptor converts the errors returned by the handlers with Public,
+// so the clients receive the codes and details of the errors, but not the internal causes.
+// The errors are localized to the accept-language metadata.
+func GRPCServerInterceptor() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Public(withGRPCLocale(ctx), err, "method", info.FullMethod)
+		}
+
+		return resp, nil
+	})
+}
+
+func GRPCServerStreamInterceptor() grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Public(withGRPCLocale(ss.Context()), err, "method", info.FullMethod)
+		}
+
+		return nil
+	})
+}
+
+func withGRPCLocale(ctx context.Context) context.Context {
+	return WithLocale(ctx, NegotiateLocale(metadata.ValueFromIncomingContext(ctx, "accept-language")...))
+}
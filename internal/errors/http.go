@@ -0,0 +1,14 @@
+package errors
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware places the locale negotiated from the Accept-Language header in the context of the request,
+// so the errors rendered with Public are localized to it.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), NegotiateLocale(c.Request.Header.Values("Accept-Language")...)))
+		c.Next()
+	}
+}
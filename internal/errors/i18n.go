@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// DefaultLocale is the locale of the messages if the client doesn't accept any supported locale.
+const DefaultLocale = "en"
+
+// LocalizedMessage is the message of an error for the users, in their locale.
+type LocalizedMessage struct {
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+// The message catalogs of the supported locales, named by the locales.
+// The messages are keyed by the error reasons, or by the code names like NOT_FOUND for the errors without reasons.
+//
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	catalogs = map[string]map[string]string{}
+	matcher  language.Matcher
+)
+
+func init() {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	// The default locale is the first one, so the matcher falls back to it.
+	tags := []language.Tag{language.Make(DefaultLocale)}
+	for _, f := range files {
+		b, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		locale := strings.TrimSuffix(f.Name(), ".json")
+
+		var catalog map[string]string
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			panic(fmt.Sprintf("errors: decode catalog %s: %v", f.Name(), err))
+		}
+		catalogs[locale] = catalog
+
+		if locale != DefaultLocale {
+			tags = append(tags, language.Make(locale))
+		}
+	}
+
+	matcher = language.NewMatcher(tags)
+}
+
+// NegotiateLocale returns the supported locale matching the Accept-Language values best.
+func NegotiateLocale(acceptLanguages ...string) string {
+	var tags []language.Tag
+	for _, v := range acceptLanguages {
+		// Invalid values are ignored, the valid tags are still returned.
+		t, _, _ := language.ParseAcceptLanguage(v)
+		tags = append(tags, t...)
+	}
+
+	tag, _, _ := matcher.Match(tags...)
+	base, _ := tag.Base()
+
+	if _, ok := catalogs[base.String()]; ok {
+		return base.String()
+	}
+
+	return DefaultLocale
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context with the locale of the client, the errors returned by Public are localized to it.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale of the client in the context, or the default locale.
+func Locale(ctx context.Context) string {
+	if l, ok := ctx.Value(localeKey{}).(string); ok {
+		return l
+	}
+
+	return DefaultLocale
+}
+
+// Localize returns a copy of the error with the message for the users in the locale,
+// the message of the reason, or of the code if there is no message for the reason.
+func (e *Error) Localize(locale string) *Error {
+	catalog, ok := catalogs[locale]
+	if !ok {
+		locale, catalog = DefaultLocale, catalogs[DefaultLocale]
+	}
+
+	msg, ok := catalog[e.Reason]
+	if !ok {
+		msg, ok = catalog[code.Code(e.Code).String()]
+	}
+	if !ok {
+		return e
+	}
+
+	l := *e
+	l.LocalizedMessage = &LocalizedMessage{Locale: locale, Message: msg}
+
+	return &l
+}
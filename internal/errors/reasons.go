@@ -0,0 +1,16 @@
+package errors
+
+// The reasons of the errors, they are stable so clients can handle them.
+const (
+	ReasonTokenMissing           = "TOKEN_MISSING"
+	ReasonTokenInvalid           = "TOKEN_INVALID"
+	ReasonNotQuizMaster          = "NOT_QUIZ_MASTER"
+	ReasonNotParticipant         = "NOT_PARTICIPANT"
+	ReasonUserMismatch           = "USER_MISMATCH"
+	ReasonRateLimited            = "RATE_LIMITED"
+	ReasonSessionNotFound        = "SESSION_NOT_FOUND"
+	ReasonQuestionNotFound       = "QUESTION_NOT_FOUND"
+	ReasonLeaderboardNotFound    = "LEADERBOARD_NOT_FOUND"
+	ReasonAnswerAlreadySubmitted = "ANSWER_ALREADY_SUBMITTED"
+	ReasonNotificationsTruncated = "NOTIFICATIONS_TRUNCATED"
+)
@@ -0,0 +1,152 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultVersion = 1
+
+// Metadata describes an occurrence of an event, independent of its payload.
+type Metadata struct {
+	// ID is a UUIDv7 of the occurrence, handlers can use it to deduplicate events.
+	ID string `json:"id"`
+	// OccurredAt is the time the event was published.
+	OccurredAt time.Time `json:"occurred_at"`
+	// Version is the schema version of the payload, see Versioner.
+	Version int `json:"version"`
+	// Source is the component which published the event.
+	Source string `json:"source,omitempty"`
+	// TraceID is the trace ID of the publishing context, if any.
+	TraceID string `json:"trace_id,omitempty"`
+	// CorrelationID is shared by all events caused by the same request.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// CausationID is the ID of the event whose handler published this event, if any.
+	CausationID string `json:"causation_id,omitempty"`
+}
+
+// Envelope wraps an event with its metadata, see EnvelopeFromContext.
+type Envelope struct {
+	Metadata
+	Event Event
+}
+
+// Versioner declares the schema version of an event payload, the default is 1.
+type Versioner interface {
+	Version() int
+}
+
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	if e.Event == nil {
+		return nil, fmt.Errorf("event: marshal envelope %s: nil event", e.ID)
+	}
+
+	return json.Marshal(struct {
+		Metadata
+		Name string `json:"name"`
+		Data Event  `json:"data"`
+	}{
+		Metadata: e.Metadata,
+		Name:     e.Event.Name(),
+		Data:     e.Event,
+	})
+}
+
+// UnmarshalJSON decodes an envelope with the payload as a RawEvent.
+func (e *Envelope) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Metadata
+		Name string          `json:"name"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	e.Metadata = v.Metadata
+	e.Event = RawEvent{EventName: v.Name, Data: v.Data}
+	return nil
+}
+
+// RawEvent is an event decoded from a serialized envelope, the payload is kept as raw JSON.
+type RawEvent struct {
+	EventName string
+	Data      json.RawMessage
+}
+
+func (e RawEvent) Name() string { return e.EventName }
+
+func (e RawEvent) MarshalJSON() ([]byte, error) {
+	if e.Data == nil {
+		return []byte("null"), nil
+	}
+
+	return e.Data, nil
+}
+
+type (
+	envelopeKey      struct{}
+	correlationIDKey struct{}
+)
+
+// EnvelopeFromContext returns the envelope of the event being handled.
+func EnvelopeFromContext(ctx context.Context) (Envelope, bool) {
+	env, ok := ctx.Value(envelopeKey{}).(Envelope)
+	return env, ok
+}
+
+// WithCorrelationID returns a context whose published events are correlated by the given ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+func newEnvelope(ctx context.Context, source string, e Event) (Envelope, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return Envelope{}, fmt.Errorf("generate event ID: %w", err)
+	}
+
+	m := Metadata{
+		ID:         id.String(),
+		OccurredAt: time.Now(),
+		Version:    defaultVersion,
+		Source:     source,
+	}
+
+	if v, ok := e.(Versioner); ok {
+		m.Version = v.Version()
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		m.TraceID = sc.TraceID().String()
+	}
+
+	// Events published while handling another event inherit its correlation.
+	if parent, ok := EnvelopeFromContext(ctx); ok {
+		m.CausationID = parent.ID
+		m.CorrelationID = parent.CorrelationID
+		if m.TraceID == "" {
+			m.TraceID = parent.TraceID
+		}
+	}
+
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok && id != "" {
+		m.CorrelationID = id
+	}
+
+	if m.CorrelationID == "" {
+		m.CorrelationID = m.ID
+	}
+
+	return Envelope{Metadata: m, Event: e}, nil
+}
+
+func withEnvelope(ctx context.Context, env Envelope) context.Context {
+	return context.WithValue(ctx, envelopeKey{}, env)
+}
@@ -2,88 +2,559 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"runtime/debug"
+	"slices"
 	"sync"
 	"time"
 )
 
 const (
-	defaultPoolSize = 10000
-	defaultTimeout  = 30 * time.Second
+	defaultPoolSize  = 10000
+	defaultQueueSize = 10000
+	defaultTimeout   = 30 * time.Second
+)
+
+var (
+	// ErrStopped is returned when publishing to a stopped bus.
+	ErrStopped = errors.New("event: bus is stopped")
+	// ErrOverflow is returned when an event is dropped because the queue is full.
+	ErrOverflow = errors.New("event: queue is full")
 )
 
 type Event interface {
 	Name() string
 }
 
+// Handler handles an event, the envelope of the event can be retrieved with EnvelopeFromContext.
 type Handler func(ctx context.Context, e Event) error
 
-// Bus is an in-memory event bus.
+// Bus is an in-memory event bus, see WithPoolSize, WithQueueSize and WithOverflow.
 type Bus struct {
-	pool     chan struct{}
-	wg       *sync.WaitGroup
+	source      string
+	middlewares []Middleware
+	poolSize    int
+	queueSize   int
+	overflow    Overflow
+
 	mu       sync.RWMutex
-	handlers map[string][]Handler
+	handlers map[string][]*Subscription
+
+	qmu     sync.Mutex
+	queue   []*delivery
+	workers int
+	// inflight is the number of accepted deliveries which are not handled yet.
+	inflight int
+	// partitions holds the pending deliveries of the partitions being handled.
+	partitions map[partitionKey][]*delivery
+	// backlog is the number of pending deliveries in the partitions, they count against the queue size like the queue.
+	backlog int
+	// changed is closed and replaced whenever a delivery leaves the queue or is handled.
+	changed chan struct{}
+	state   state
+}
+
+type state int
+
+const (
+	stateRunning state = iota
+	// stateStopping only accepts events published by handlers, so cascading events are still delivered.
+	stateStopping
+	stateStopped
+)
+
+// Subscription is a handler subscribed to an event, it can be removed from the bus with Unsubscribe.
+type Subscription struct {
+	bus  *Bus
+	name string
+
+	h           Handler
+	key         func(Event) string
+	middlewares []Middleware
+	timeout     time.Duration
+}
+
+type delivery struct {
+	ctx context.Context
+	env Envelope
+	sub *Subscription
+	key string
+}
+
+type partitionKey struct {
+	sub *Subscription
+	key string
 }
 
 // NewBus create a new event bus. Caller should call Stop for graceful shutdown the bus.
-func NewBus() *Bus {
-	return &Bus{
-		pool:     make(chan struct{}, defaultPoolSize),
-		wg:       new(sync.WaitGroup),
-		handlers: make(map[string][]Handler),
+func NewBus(opts ...Option) *Bus {
+	b := &Bus{
+		middlewares: []Middleware{Recover(), Log(slog.Default())},
+		poolSize:    defaultPoolSize,
+		queueSize:   defaultQueueSize,
+		overflow:    OverflowBlock,
+		handlers:    make(map[string][]*Subscription),
+		partitions:  make(map[partitionKey][]*delivery),
+		changed:     make(chan struct{}),
 	}
+
+	for _, opt := range opts {
+		opt.apply(b)
+	}
+
+	return b
+}
+
+type Option interface {
+	apply(*Bus)
+}
+
+type optionFunc func(*Bus)
+
+func (f optionFunc) apply(b *Bus) {
+	f(b)
+}
+
+// WithSource sets the source of all events published by the bus.
+func WithSource(source string) Option {
+	return optionFunc(func(b *Bus) {
+		b.source = source
+	})
+}
+
+// WithMiddleware replaces the default middlewares Recover and Log, the first middleware is the outermost one.
+func WithMiddleware(mws ...Middleware) Option {
+	return optionFunc(func(b *Bus) {
+		b.middlewares = mws
+	})
+}
+
+// WithPoolSize sets the maximum number of events handled concurrently, the default is 10000.
+func WithPoolSize(n int) Option {
+	return optionFunc(func(b *Bus) {
+		b.poolSize = max(n, 1)
+	})
+}
+
+// WithQueueSize sets the maximum number of events waiting to be handled, the default is 10000.
+func WithQueueSize(n int) Option {
+	return optionFunc(func(b *Bus) {
+		b.queueSize = max(n, 1)
+	})
+}
+
+// WithOverflow sets the overflow policy, the default is OverflowBlock.
+func WithOverflow(o Overflow) Option {
+	return optionFunc(func(b *Bus) {
+		b.overflow = o
+	})
+}
+
+// Overflow is the policy applied when publishing to a full queue.
+type Overflow struct {
+	policy  overflowPolicy
+	timeout time.Duration
+}
+
+type overflowPolicy int
+
+const (
+	overflowBlock overflowPolicy = iota
+	overflowDropOldest
+	overflowDropNewest
+)
+
+var (
+	// OverflowBlock blocks the publisher until there is space in the queue or its context is done.
+	OverflowBlock = Overflow{policy: overflowBlock}
+	// OverflowDropOldest drops the oldest queued event to make space for the published one.
+	OverflowDropOldest = Overflow{policy: overflowDropOldest}
+	// OverflowDropNewest drops the published event, Publish returns ErrOverflow.
+	OverflowDropNewest = Overflow{policy: overflowDropNewest}
+)
+
+// OverflowBlockTimeout blocks the publisher for at most d, then drops the published event like OverflowDropNewest.
+func OverflowBlockTimeout(d time.Duration) Overflow {
+	return Overflow{policy: overflowBlock, timeout: d}
+}
+
+type SubscribeOption interface {
+	apply(*Subscription)
+}
+
+type subscribeOptionFunc func(*Subscription)
+
+func (f subscribeOptionFunc) apply(s *Subscription) {
+	f(s)
+}
+
+// WithPartitionKey handles the events with the same key sequentially, in the order they were published.
+func WithPartitionKey(key func(Event) string) SubscribeOption {
+	return subscribeOptionFunc(func(s *Subscription) {
+		s.key = key
+	})
+}
+
+// WithHandlerMiddleware adds middlewares to the handler, they are applied inside the bus middlewares.
+func WithHandlerMiddleware(mws ...Middleware) SubscribeOption {
+	return subscribeOptionFunc(func(s *Subscription) {
+		s.middlewares = append(s.middlewares, mws...)
+	})
+}
+
+// WithTimeout sets the timeout for handling an event, the default is 30 seconds and non-positive disables it.
+func WithTimeout(d time.Duration) SubscribeOption {
+	return subscribeOptionFunc(func(s *Subscription) {
+		s.timeout = d
+	})
 }
 
 // Subscribe to an event
-func (b *Bus) Subscribe(name string, h Handler) {
+func (b *Bus) Subscribe(name string, h Handler, opts ...SubscribeOption) *Subscription {
+	s := &Subscription{
+		bus:     b,
+		name:    name,
+		timeout: defaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+
+	mws := make([]Middleware, 0, len(b.middlewares)+len(s.middlewares)+1)
+	mws = append(mws, b.middlewares...)
+	mws = append(mws, s.middlewares...)
+	mws = append(mws, Timeout(s.timeout))
+	s.h = chain(h, mws...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], s)
+	return s
+}
+
+// Unsubscribe removes the handler from the bus, events already published to the handler are not affected.
+func (s *Subscription) Unsubscribe() {
+	b := s.bus
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	b.handlers[name] = append(b.handlers[name], h)
+	// Copy on write, the handlers may be read by Publish without holding the lock.
+	b.handlers[s.name] = slices.DeleteFunc(slices.Clone(b.handlers[s.name]), func(h *Subscription) bool {
+		return h == s
+	})
+	if len(b.handlers[s.name]) == 0 {
+		delete(b.handlers, s.name)
+	}
+}
+
+// Subscribe subscribes a typed handler to the events named by the zero value of T.
+func Subscribe[T Event](b *Bus, h func(ctx context.Context, e T) error, opts ...SubscribeOption) *Subscription {
+	var zero T
+
+	return b.Subscribe(zero.Name(), func(ctx context.Context, e Event) error {
+		t, ok := e.(T)
+		if !ok {
+			return fmt.Errorf("event: unexpected type %T of event %s, want %T", e, e.Name(), zero)
+		}
+
+		return h(ctx, t)
+	}, opts...)
+}
+
+// PartitionBy is the typed version of WithPartitionKey.
+func PartitionBy[T Event](key func(T) string) SubscribeOption {
+	return WithPartitionKey(func(e Event) string {
+		// Events of another type are rejected by the typed handler, their key doesn't matter.
+		t, _ := e.(T)
+		return key(t)
+	})
 }
 
-// Publish an event
-func (b *Bus) Publish(ctx context.Context, e Event) {
+// Publish an event to all of its handlers, it returns ErrStopped or ErrOverflow if the event is not queued.
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	env, err := newEnvelope(ctx, b.source, e)
+	if err != nil {
+		return fmt.Errorf("event: publish %s: %w", e.Name(), err)
+	}
+
 	b.mu.RLock()
-	defer b.mu.RUnlock()
+	subs := b.handlers[e.Name()]
+	b.mu.RUnlock()
 
-	for _, h := range b.handlers[e.Name()] {
+	var errs []error
+	for _, s := range subs {
 		// TODO: isolate pool size for each handler, so a slow handler won't block other handlers
-		b.dispatch(ctx, h, e)
+		d := &delivery{ctx: ctx, env: env, sub: s}
+		if s.key != nil {
+			d.key = s.key(e)
+		}
+
+		if err := b.enqueue(d); err != nil {
+			errs = append(errs, err)
+		}
 	}
+
+	return errors.Join(errs...)
 }
 
-func (b *Bus) dispatch(ctx context.Context, h Handler, e Event) {
-	b.wg.Add(1)
+func (b *Bus) enqueue(d *delivery) error {
+	var timeout <-chan time.Time
+	if b.overflow.timeout > 0 {
+		t := time.NewTimer(b.overflow.timeout)
+		defer t.Stop()
+		timeout = t.C
+	}
 
-	b.pool <- struct{}{}
+	b.qmu.Lock()
+	defer b.qmu.Unlock()
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultTimeout)
-		defer func() {
-			if r := recover(); r != nil {
-				slog.ErrorContext(ctx, "event: handler panic",
-					"error", fmt.Errorf("%v, stack: %s", r, debug.Stack()),
-				)
-			}
+	for {
+		if err := b.accepting(d); err != nil {
+			droppedTotal.WithLabelValues(d.env.Event.Name(), "stopped").Inc()
+			return err
+		}
 
-			cancel()
-			<-b.pool
-			b.wg.Done()
-		}()
+		if len(b.queue)+b.backlog < b.queueSize {
+			break
+		}
 
-		if err := h(ctx, e); err != nil {
-			slog.ErrorContext(ctx, "event: handle event failed",
-				"error", err,
+		switch b.overflow.policy {
+		case overflowDropNewest:
+			droppedTotal.WithLabelValues(d.env.Event.Name(), "overflow").Inc()
+			return ErrOverflow
+
+		case overflowDropOldest:
+			oldest := b.evictOldest()
+			b.inflight--
+			droppedTotal.WithLabelValues(oldest.env.Event.Name(), "evicted").Inc()
+			slog.WarnContext(oldest.ctx, "event: dropped oldest event, queue is full",
+				"event", oldest.env.Event.Name(),
+				"event_id", oldest.env.ID,
 			)
+
+		default:
+			changed := b.changed
+			b.qmu.Unlock()
+
+			var err error
+			select {
+			case <-changed:
+			case <-timeout:
+				err = ErrOverflow
+			case <-d.ctx.Done():
+				err = fmt.Errorf("%w: %w", ErrOverflow, d.ctx.Err())
+			}
+
+			b.qmu.Lock()
+			if err != nil {
+				droppedTotal.WithLabelValues(d.env.Event.Name(), "overflow").Inc()
+				return err
+			}
+		}
+	}
+
+	b.queue = append(b.queue, d)
+	b.inflight++
+
+	if b.workers < b.poolSize {
+		b.workers++
+		go b.work()
+	}
+
+	return nil
+}
+
+func (b *Bus) accepting(d *delivery) error {
+	switch b.state {
+	case stateRunning:
+		return nil
+	case stateStopping:
+		if _, ok := EnvelopeFromContext(d.ctx); ok {
+			return nil
+		}
+	}
+
+	return ErrStopped
+}
+
+func (b *Bus) work() {
+	b.qmu.Lock()
+	defer b.qmu.Unlock()
+
+	for {
+		d, ok := b.dequeue()
+		if !ok {
+			b.workers--
+			return
+		}
+
+		for ok {
+			b.qmu.Unlock()
+			b.handle(d)
+			b.qmu.Lock()
+
+			b.inflight--
+			d, ok = b.nextInPartition(d)
+			b.notify()
+		}
+	}
+}
+
+// dequeue pops the next delivery whose partition isn't being handled.
+func (b *Bus) dequeue() (*delivery, bool) {
+	for len(b.queue) > 0 {
+		d := b.queue[0]
+		b.queue[0], b.queue = nil, b.queue[1:]
+		b.notify()
+
+		if d.sub.key == nil {
+			return d, true
+		}
+
+		pk := partitionKey{sub: d.sub, key: d.key}
+		if q, ok := b.partitions[pk]; ok {
+			b.partitions[pk] = append(q, d)
+			b.backlog++
+			continue
 		}
-	}()
+
+		b.partitions[pk] = nil
+		return d, true
+	}
+
+	return nil, false
+}
+
+// nextInPartition returns the next delivery of the partition of the handled delivery, if any.
+func (b *Bus) nextInPartition(d *delivery) (*delivery, bool) {
+	if d.sub.key == nil {
+		return nil, false
+	}
+
+	pk := partitionKey{sub: d.sub, key: d.key}
+	q := b.partitions[pk]
+	if len(q) == 0 {
+		delete(b.partitions, pk)
+		return nil, false
+	}
+
+	next := q[0]
+	q[0], b.partitions[pk] = nil, q[1:]
+	b.backlog--
+	return next, true
+}
+
+// evictOldest removes the oldest pending delivery, of the queue or of the partitions.
+func (b *Bus) evictOldest() *delivery {
+	var (
+		oldest *delivery
+		from   *partitionKey
+	)
+	if len(b.queue) > 0 {
+		oldest = b.queue[0]
+	}
+
+	for pk, q := range b.partitions {
+		if len(q) > 0 && (oldest == nil || q[0].env.OccurredAt.Before(oldest.env.OccurredAt)) {
+			pk := pk
+			oldest, from = q[0], &pk
+		}
+	}
+
+	if from == nil {
+		b.queue[0], b.queue = nil, b.queue[1:]
+		return oldest
+	}
+
+	q := b.partitions[*from]
+	q[0], b.partitions[*from] = nil, q[1:]
+	b.backlog--
+	return oldest
+}
+
+func (b *Bus) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *Bus) handle(d *delivery) {
+	// Errors are reported by the middlewares, e.g. Log.
+	_ = d.sub.h(withEnvelope(context.WithoutCancel(d.ctx), d.env), d.env.Event)
+}
+
+// Stop waits for the published events to be handled, or returns the pending ones in an UndeliveredError.
+func (b *Bus) Stop(ctx context.Context) error {
+	b.qmu.Lock()
+	defer b.qmu.Unlock()
+
+	if b.state == stateRunning {
+		b.state = stateStopping
+	}
+
+	for b.inflight > 0 {
+		changed := b.changed
+		b.qmu.Unlock()
+
+		select {
+		case <-changed:
+			b.qmu.Lock()
+		case <-ctx.Done():
+			b.qmu.Lock()
+			b.state = stateStopped
+			return b.drop(ctx.Err())
+		}
+	}
+
+	b.state = stateStopped
+	return nil
+}
+
+func (b *Bus) drop(cause error) error {
+	pending := b.queue
+	for _, q := range b.partitions {
+		pending = append(pending, q...)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := &UndeliveredError{
+		Envelopes: make([]Envelope, 0, len(pending)),
+		err:       cause,
+	}
+	for _, d := range pending {
+		err.Envelopes = append(err.Envelopes, d.env)
+		droppedTotal.WithLabelValues(d.env.Event.Name(), "stopped").Inc()
+	}
+
+	b.queue = nil
+	for pk := range b.partitions {
+		// Keep the partitions being handled, so their workers can finish them.
+		b.partitions[pk] = nil
+	}
+	b.backlog = 0
+	b.inflight -= len(pending)
+	b.notify()
+
+	return err
+}
+
+// UndeliveredError is returned by Stop if some events are not handled before the deadline.
+type UndeliveredError struct {
+	// Envelopes are the events which are dropped, an event is repeated for each of its undelivered handlers.
+	Envelopes []Envelope
+	err       error
+}
+
+func (e *UndeliveredError) Error() string {
+	return fmt.Sprintf("event: %d undelivered events: %v", len(e.Envelopes), e.err)
 }
 
-// Stop waits for all handlers to finish
-func (b *Bus) Stop() {
-	b.wg.Wait()
+func (e *UndeliveredError) Unwrap() error {
+	return e.err
 }
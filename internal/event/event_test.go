@@ -2,10 +2,13 @@ package event_test
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/victornm/equiz/internal/event"
 )
@@ -154,15 +157,330 @@ func TestBus_PublishSubscribe(t *testing.T) {
 			}
 
 			for _, e := range in.published {
-				b.Publish(context.Background(), e)
+				require.NoError(t, b.Publish(context.Background(), e))
 			}
-			b.Stop()
+			require.NoError(t, b.Stop(context.Background()))
 
 			tt.assert(t, out)
 		})
 	}
 }
 
+func TestBus_Envelope(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = make(map[string]event.Envelope)
+	)
+
+	record := func(ctx context.Context, e event.Event) error {
+		env, ok := event.EnvelopeFromContext(ctx)
+		require.True(t, ok, "handler should receive the envelope of the event")
+
+		mu.Lock()
+		received[e.Name()] = env
+		mu.Unlock()
+		return nil
+	}
+
+	b := event.NewBus(event.WithSource("test"))
+	b.Subscribe("e1", func(ctx context.Context, e event.Event) error {
+		assert.NoError(t, b.Publish(ctx, eventWithName("e2")))
+		return record(ctx, e)
+	})
+	b.Subscribe("e2", record)
+
+	require.NoError(t, b.Publish(event.WithCorrelationID(context.Background(), "c1"), eventWithName("e1")))
+	require.NoError(t, b.Stop(context.Background()))
+
+	e1, e2 := received["e1"], received["e2"]
+	assert.NotEmpty(t, e1.ID)
+	assert.NotEqual(t, e1.ID, e2.ID, "each event should have a unique ID")
+	assert.False(t, e1.OccurredAt.IsZero())
+	assert.Equal(t, 1, e1.Version)
+	assert.Equal(t, "test", e1.Source)
+	assert.Equal(t, "c1", e1.CorrelationID)
+	assert.Equal(t, "c1", e2.CorrelationID, "correlation ID should be propagated to caused events")
+	assert.Equal(t, e1.ID, e2.CausationID)
+
+	b1, err := json.Marshal(e1)
+	require.NoError(t, err)
+
+	var decoded event.Envelope
+	require.NoError(t, json.Unmarshal(b1, &decoded))
+	assert.Equal(t, e1.ID, decoded.ID)
+	assert.Equal(t, "e1", decoded.Event.Name())
+
+	_, err = json.Marshal(event.Envelope{Metadata: e1.Metadata})
+	assert.Error(t, err, "envelope without event should not be marshaled")
+}
+
+func TestBus_PartitionKey(t *testing.T) {
+	const n = 100
+
+	var (
+		mu       sync.Mutex
+		received = make(map[string][]int)
+		blocked  = make(chan struct{})
+	)
+
+	b := event.NewBus()
+	b.Subscribe("keyed", func(_ context.Context, e event.Event) error {
+		ke := e.(keyedEvent)
+		if ke.key == "blocked" {
+			<-blocked
+		}
+
+		mu.Lock()
+		received[ke.key] = append(received[ke.key], ke.seq)
+		mu.Unlock()
+		return nil
+	}, event.WithPartitionKey(func(e event.Event) string {
+		return e.(keyedEvent).key
+	}))
+
+	require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "blocked"}))
+	for i := range n {
+		require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: i}))
+		require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "b", seq: i}))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received["a"]) == n && len(received["b"]) == n
+	}, time.Second, 10*time.Millisecond, "a blocked partition should not block other partitions")
+
+	close(blocked)
+	require.NoError(t, b.Stop(context.Background()))
+
+	for _, key := range []string{"a", "b"} {
+		for i, seq := range received[key] {
+			require.Equal(t, i, seq, "events of partition %s should be handled in order", key)
+		}
+	}
+}
+
+func TestBus_Middleware(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	record := func(name string) event.Middleware {
+		return func(next event.Handler) event.Handler {
+			return func(ctx context.Context, e event.Event) error {
+				mu.Lock()
+				calls = append(calls, name)
+				mu.Unlock()
+				return next(ctx, e)
+			}
+		}
+	}
+
+	var deadline time.Time
+	b := event.NewBus(event.WithMiddleware(event.Recover(), record("global")))
+	b.Subscribe("e1", func(ctx context.Context, _ event.Event) error {
+		deadline, _ = ctx.Deadline()
+		panic("boom")
+	},
+		event.WithHandlerMiddleware(record("handler")),
+		event.WithTimeout(time.Minute),
+	)
+
+	require.NoError(t, b.Publish(context.Background(), eventWithName("e1")))
+	require.NoError(t, b.Stop(context.Background()))
+
+	assert.Equal(t, []string{"global", "handler"}, calls, "bus middlewares should wrap handler middlewares")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestSubscribe_Typed(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []keyedEvent
+	)
+
+	b := event.NewBus()
+	sub := event.Subscribe(b, func(_ context.Context, e keyedEvent) error {
+		mu.Lock()
+		received = append(received, e)
+		mu.Unlock()
+		return nil
+	})
+
+	require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: 1}))
+	require.NoError(t, b.Publish(context.Background(), eventWithName("keyed"))) // same name, another type: rejected, not panic
+
+	sub.Unsubscribe()
+	require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: 2}))
+	require.NoError(t, b.Stop(context.Background()))
+
+	assert.Equal(t, []keyedEvent{{key: "a", seq: 1}}, received)
+}
+
+func TestBus_Overflow(t *testing.T) {
+	tests := map[string]struct {
+		overflow event.Overflow
+		wantErr  error
+		// want is the sequences handled after the first blocking event.
+		want []int
+	}{
+		"drop newest should reject the published event": {
+			overflow: event.OverflowDropNewest,
+			wantErr:  event.ErrOverflow,
+			want:     []int{1},
+		},
+		"drop oldest should evict the queued event": {
+			overflow: event.OverflowDropOldest,
+			want:     []int{2},
+		},
+		"block with timeout should reject the published event after timeout": {
+			overflow: event.OverflowBlockTimeout(10 * time.Millisecond),
+			wantErr:  event.ErrOverflow,
+			want:     []int{1},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu       sync.Mutex
+				received []int
+				started  = make(chan struct{})
+				blocked  = make(chan struct{})
+			)
+
+			b := event.NewBus(event.WithPoolSize(1), event.WithQueueSize(1), event.WithOverflow(tt.overflow))
+			event.Subscribe(b, func(_ context.Context, e keyedEvent) error {
+				if e.seq == 0 {
+					close(started)
+					<-blocked
+					return nil
+				}
+
+				mu.Lock()
+				received = append(received, e.seq)
+				mu.Unlock()
+				return nil
+			})
+
+			require.NoError(t, b.Publish(context.Background(), keyedEvent{seq: 0}))
+			<-started
+			require.NoError(t, b.Publish(context.Background(), keyedEvent{seq: 1}))
+			require.ErrorIs(t, b.Publish(context.Background(), keyedEvent{seq: 2}), tt.wantErr)
+
+			close(blocked)
+			require.NoError(t, b.Stop(context.Background()))
+			assert.Equal(t, tt.want, received)
+		})
+	}
+}
+
+func TestBus_OverflowPartitioned(t *testing.T) {
+	tests := map[string]struct {
+		overflow event.Overflow
+		wantErr  error
+		want     []int
+	}{
+		"drop newest should reject the published event": {
+			overflow: event.OverflowDropNewest,
+			wantErr:  event.ErrOverflow,
+			want:     []int{1, 2, 3},
+		},
+		"drop oldest should evict the oldest event of the partition": {
+			overflow: event.OverflowDropOldest,
+			want:     []int{2, 3, 4},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu       sync.Mutex
+				received []int
+				started  = make(chan struct{})
+				blocked  = make(chan struct{})
+				handled  = make(chan struct{})
+			)
+
+			b := event.NewBus(event.WithPoolSize(2), event.WithQueueSize(3), event.WithOverflow(tt.overflow))
+			event.Subscribe(b, func(_ context.Context, e keyedEvent) error {
+				switch {
+				case e.key == "b":
+					handled <- struct{}{}
+				case e.seq == 0:
+					close(started)
+					<-blocked
+				default:
+					mu.Lock()
+					received = append(received, e.seq)
+					mu.Unlock()
+				}
+				return nil
+			}, event.PartitionBy(func(e keyedEvent) string { return e.key }))
+
+			require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: 0}))
+			<-started
+
+			// The events waiting for the blocked partition still take the queue.
+			// The event of another partition is handled after the previous event is moved to the blocked partition.
+			for seq := 1; seq <= 2; seq++ {
+				require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: seq}))
+				require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "b"}))
+				<-handled
+			}
+			require.NoError(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: 3}))
+			require.ErrorIs(t, b.Publish(context.Background(), keyedEvent{key: "a", seq: 4}), tt.wantErr)
+
+			close(blocked)
+			require.NoError(t, b.Stop(context.Background()))
+			assert.Equal(t, tt.want, received)
+		})
+	}
+}
+
+func TestBus_Stop(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	b := event.NewBus(event.WithPoolSize(1))
+	event.Subscribe(b, func(_ context.Context, e keyedEvent) error {
+		if e.seq == 0 {
+			<-blocked
+		}
+		return nil
+	})
+
+	require.NoError(t, b.Publish(context.Background(), keyedEvent{seq: 0}))
+	require.NoError(t, b.Publish(context.Background(), keyedEvent{seq: 1}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := b.Stop(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var undelivered *event.UndeliveredError
+	require.ErrorAs(t, err, &undelivered)
+	require.Len(t, undelivered.Envelopes, 1)
+	assert.Equal(t, keyedEvent{seq: 1}, undelivered.Envelopes[0].Event)
+
+	require.ErrorIs(t, b.Publish(context.Background(), keyedEvent{seq: 2}), event.ErrStopped)
+}
+
+type keyedEvent struct {
+	key string
+	seq int
+}
+
+func (keyedEvent) Name() string { return "keyed" }
+
 type eventWithName string
 
 func (e eventWithName) Name() string {
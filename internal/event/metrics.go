@@ -0,0 +1,30 @@
+package event
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	handledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "equiz",
+		Subsystem: "event",
+		Name:      "handled_total",
+		Help:      "Total number of handled events by event name and result.",
+	}, []string{"event", "result"})
+
+	handleDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "equiz",
+		Subsystem: "event",
+		Name:      "handle_duration_seconds",
+		Help:      "Duration of handling events by event name.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"event"})
+
+	droppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "equiz",
+		Subsystem: "event",
+		Name:      "dropped_total",
+		Help:      "Total number of dropped events by event name and reason.",
+	}, []string{"event", "reason"})
+)
@@ -0,0 +1,136 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware wraps a handler to add cross-cutting behaviors.
+type Middleware func(Handler) Handler
+
+func chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	return h
+}
+
+// Recover recovers the handler from panic, the panic is logged and returned as an error.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, e Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("event: handler panic: %v, stack: %s", r, debug.Stack())
+					slog.ErrorContext(ctx, "event: handler panic",
+						logAttrs(ctx, e, "error", err)...,
+					)
+				}
+			}()
+
+			return next(ctx, e)
+		}
+	}
+}
+
+// Log logs the failures of the handler.
+func Log(l *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, e Event) error {
+			start := time.Now()
+			err := next(ctx, e)
+			if err != nil {
+				l.ErrorContext(ctx, "event: handle event failed",
+					logAttrs(ctx, e, "error", err)...,
+				)
+				return err
+			}
+
+			l.DebugContext(ctx, "event: handled event",
+				logAttrs(ctx, e, "duration", time.Since(start))...,
+			)
+			return nil
+		}
+	}
+}
+
+func logAttrs(ctx context.Context, e Event, args ...any) []any {
+	attrs := []any{"event", e.Name()}
+	if env, ok := EnvelopeFromContext(ctx); ok {
+		attrs = append(attrs, "event_id", env.ID, "correlation_id", env.CorrelationID)
+	}
+
+	return append(attrs, args...)
+}
+
+// Trace starts a span for each handled event, as a child of the publishing span if any.
+func Trace() Middleware {
+	tracer := otel.Tracer("github.com/victornm/equiz/internal/event")
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, e Event) error {
+			attrs := []attribute.KeyValue{attribute.String("messaging.destination.name", e.Name())}
+			if env, ok := EnvelopeFromContext(ctx); ok {
+				attrs = append(attrs, attribute.String("messaging.message.id", env.ID))
+			}
+
+			ctx, span := tracer.Start(ctx, e.Name()+" process",
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			err := next(ctx, e)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return err
+		}
+	}
+}
+
+// Metrics records the number of handled events and the handling duration.
+func Metrics() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, e Event) error {
+			start := time.Now()
+			err := next(ctx, e)
+			handleDuration.WithLabelValues(e.Name()).Observe(time.Since(start).Seconds())
+
+			result := "success"
+			if err != nil {
+				result = "failure"
+			}
+			handledTotal.WithLabelValues(e.Name(), result).Inc()
+
+			return err
+		}
+	}
+}
+
+// Timeout cancels the context of the handler after the given duration, a non-positive duration disables it.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		if d <= 0 {
+			return next
+		}
+
+		return func(ctx context.Context, e Event) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			return next(ctx, e)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package leaderboard
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -14,15 +15,17 @@ import (
 )
 
 const (
-	publishInterval = 200 * time.Millisecond
+	defaultPublishInterval = 200 * time.Millisecond
 )
 
 type Config struct {
-	EventBus      *event.Bus
-	Score         *score.Service
-	Redis         redis.UniversalClient
-	Prefix        string
-	NewTickerFunc func(d time.Duration) Ticker
+	EventBus *event.Bus
+	Score    *score.Service
+	Redis    redis.UniversalClient
+	Prefix   string
+	// PublishInterval is the minimum interval between the published leaderboards of a session, defaults to 200ms.
+	PublishInterval time.Duration
+	NewTickerFunc   func(d time.Duration) Ticker
 }
 
 type Ticker interface {
@@ -35,6 +38,8 @@ type Service struct {
 	score  *score.Service
 	redis  redis.UniversalClient
 	prefix string
+
+	publishInterval atomic.Int64
 }
 
 func NewService(c Config) *Service {
@@ -44,14 +49,27 @@ func NewService(c Config) *Service {
 		redis:  c.Redis,
 		prefix: c.Prefix,
 	}
+	s.SetPublishInterval(c.PublishInterval)
 
-	s.eb.Subscribe(domain.EventNameScoreUpdated, func(ctx context.Context, e event.Event) error {
-		return s.UpdateLeaderboard(ctx, e.(domain.EventScoreUpdated))
-	})
+	event.Subscribe(s.eb, s.UpdateLeaderboard,
+		// Scores of a user must be applied in order, otherwise an older total can overwrite a newer one.
+		event.PartitionBy(func(e domain.EventScoreUpdated) string {
+			return e.Score.SessionID + ":" + e.Score.Username
+		}),
+	)
 
 	return s
 }
 
+// SetPublishInterval replaces the publish interval, a non-positive interval resets it to the default.
+func (s *Service) SetPublishInterval(d time.Duration) {
+	if d <= 0 {
+		d = defaultPublishInterval
+	}
+
+	s.publishInterval.Store(int64(d))
+}
+
 type GetLeaderboardRequest struct {
 	SessionID string
 }
@@ -64,7 +82,10 @@ func (s *Service) GetLeaderboard(ctx context.Context, req GetLeaderboardRequest)
 	}
 
 	if len(res) == 0 {
-		return nil, errors.New(errors.CodeNotFound, errors.WithMessagef("leaderboard not found: session=%s", req.SessionID))
+		return nil, errors.New(errors.CodeNotFound,
+			errors.WithMessagef("leaderboard not found: session=%s", req.SessionID),
+			errors.WithReason(errors.ReasonLeaderboardNotFound, "session_id", req.SessionID),
+		)
 	}
 
 	scores := make([]domain.LeaderboardEntry, 0, len(res))
@@ -102,7 +123,7 @@ func (s *Service) UpdateLeaderboard(ctx context.Context, e domain.EventScoreUpda
 func (s *Service) schedulePublishLeaderboard(ctx context.Context, sc domain.Score) error {
 	// This is a simple way to prevent multiple instances of the service from publishing the leaderboard.
 	// But it's not perfect and can be improved.
-	ok, err := s.redis.SetNX(ctx, s.getLeaderboardTimeKey(sc.SessionID), sc.UpdateTime.UnixMilli(), publishInterval).Result()
+	ok, err := s.redis.SetNX(ctx, s.getLeaderboardTimeKey(sc.SessionID), sc.UpdateTime.UnixMilli(), time.Duration(s.publishInterval.Load())).Result()
 	if err != nil {
 		return fmt.Errorf("setnx: %w", err)
 	}
@@ -122,17 +143,20 @@ func (s *Service) publishLeaderboard(ctx context.Context, sc domain.Score) error
 		return fmt.Errorf("get leaderboard failed: session=%s: %w", sc.SessionID, err)
 	}
 
-	s.eb.Publish(ctx, domain.EventLeaderboardUpdated{
+	if err := s.eb.Publish(ctx, domain.EventLeaderboardUpdated{
 		Leaderboard: *l,
-	})
+	}); err != nil {
+		return fmt.Errorf("publish leaderboard updated: session=%s: %w", sc.SessionID, err)
+	}
 
-	return s.redis.Set(ctx, s.getLeaderboardTimeKey(sc.SessionID), sc.UpdateTime.UnixMilli(), publishInterval).Err()
+	return s.redis.Set(ctx, s.getLeaderboardTimeKey(sc.SessionID), sc.UpdateTime.UnixMilli(), time.Duration(s.publishInterval.Load())).Err()
 }
 
+// The keys of a session are hash-tagged by the session, so they are in the same slot of Redis Cluster.
 func (s *Service) getLeaderboardKey(session string) string {
-	return fmt.Sprintf("%s:%s:leaderboard", s.prefix, session)
+	return fmt.Sprintf("%s:{%s}:leaderboard", s.prefix, session)
 }
 
 func (s *Service) getLeaderboardTimeKey(session string) string {
-	return fmt.Sprintf("%s:%s:time", s.prefix, session)
+	return fmt.Sprintf("%s:{%s}:time", s.prefix, session)
 }
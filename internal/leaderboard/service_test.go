@@ -153,9 +153,9 @@ func TestServer_PublishLeaderboardUpdated(t *testing.T) {
 			eb := event.NewBus()
 
 			var mu sync.Mutex
-			eb.Subscribe(domain.EventNameLeaderboardUpdated, func(ctx context.Context, e event.Event) error {
+			event.Subscribe(eb, func(_ context.Context, e domain.EventLeaderboardUpdated) error {
 				mu.Lock()
-				out.publishedEvents = append(out.publishedEvents, e.(domain.EventLeaderboardUpdated))
+				out.publishedEvents = append(out.publishedEvents, e)
 				mu.Unlock()
 				return nil
 			})
@@ -169,7 +169,7 @@ func TestServer_PublishLeaderboardUpdated(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			eb.Stop()
+			require.NoError(t, eb.Stop(context.Background()))
 
 			tt.assert(t, out)
 		})
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/grpc"
+
+	"github.com/victornm/equiz/internal/auth"
+)
+
+// sessionRequest is implemented by the requests with a session ID.
+type sessionRequest interface {
+	GetSessionId() string
+}
+
+// GRPCServerInterceptor limits the calls by the method, the authenticated user and the session of the request.
+// It must be chained after the authentication interceptor.
+func GRPCServerInterceptor(l *Limiter) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := l.Allow(ctx, grpcKey(ctx, info.FullMethod, req)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	})
+}
+
+// GRPCServerStreamInterceptor limits the streams like GRPCServerInterceptor,
+// the limit is checked when the request is received because the session is in the request.
+func GRPCServerStreamInterceptor(l *Limiter) grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedStream{ServerStream: ss, l: l, method: info.FullMethod})
+	})
+}
+
+type limitedStream struct {
+	grpc.ServerStream
+	l      *Limiter
+	method string
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.l.Allow(s.Context(), grpcKey(s.Context(), s.method, m))
+}
+
+func grpcKey(ctx context.Context, fullMethod string, req any) Key {
+	k := Key{Method: path.Base(fullMethod)}
+
+	if p, ok := auth.FromContext(ctx); ok {
+		k.User = p.Username
+	}
+
+	if r, ok := req.(sessionRequest); ok {
+		k.Session = r.GetSessionId()
+	}
+
+	return k
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/errors"
+)
+
+// Middleware limits the HTTP requests to the method like GRPCServerInterceptor,
+// the session is the id parameter of the path.
+// It must be used after the authentication middleware.
+func Middleware(l *Limiter, method string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		k := Key{
+			Method:  method,
+			Session: c.Param("id"),
+		}
+
+		if p, ok := auth.FromContext(c.Request.Context()); ok {
+			k.User = p.Username
+		}
+
+		if err := l.Allow(c.Request.Context(), k); err != nil {
+			e := errors.Public(c.Request.Context(), err, "method", c.Request.Method, "path", c.FullPath())
+			if e.RetryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter))))
+			}
+			c.AbortWithStatusJSON(e.HTTPStatusCode(), e)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var rejectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "equiz",
+	Subsystem: "ratelimit",
+	Name:      "rejected_total",
+	Help:      "Total number of calls rejected by the rate limiter by method.",
+}, []string{"method"})
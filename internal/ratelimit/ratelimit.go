@@ -0,0 +1,162 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/victornm/equiz/internal/errors"
+)
+
+type Config struct {
+	Redis  redis.Scripter
+	Prefix string
+	// Default is the limit of the methods without their own limit.
+	Default Limit
+	// Methods are the limits by the method names, e.g. SubmitAnswer.
+	Methods map[string]Limit
+}
+
+// Limit is a token bucket, which is refilled at Rate tokens per second up to Burst tokens.
+// Each call takes a token, a zero rate means unlimited.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter limits the calls of each user to each method within each session,
+// the token buckets are stored in Redis so they are shared by all instances.
+type Limiter struct {
+	redis  redis.Scripter
+	prefix string
+	limits atomic.Pointer[limits]
+}
+
+type limits struct {
+	def     Limit
+	methods map[string]Limit
+}
+
+func NewLimiter(c Config) *Limiter {
+	l := &Limiter{
+		redis:  c.Redis,
+		prefix: c.Prefix,
+	}
+	l.SetLimits(c.Default, c.Methods)
+
+	return l
+}
+
+// SetLimits replaces the limits, the calls in flight use either the old or the new limits.
+// The buckets are kept, so the tokens taken before are still counted.
+func (l *Limiter) SetLimits(def Limit, methods map[string]Limit) {
+	ls := &limits{def: def, methods: make(map[string]Limit, len(methods))}
+
+	// The keys of the config are case-insensitive.
+	for m, limit := range methods {
+		ls.methods[strings.ToLower(m)] = limit
+	}
+
+	l.limits.Store(ls)
+}
+
+// Key identifies a token bucket, the empty fields are not part of the key.
+// The calls without a user are public, e.g. health checks, they are not limited.
+type Key struct {
+	Method  string
+	User    string
+	Session string
+}
+
+// takeScript takes a token from the bucket, and returns whether it is allowed,
+// and the seconds to wait for the next token if not.
+// The time of Redis is used, so the buckets don't depend on the clocks of the instances.
+//
+// KEYS[1]: bucket key.
+// ARGV[1]: rate per second, ARGV[2]: burst.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'time')
+local tokens = tonumber(bucket[1]) or burst
+local last = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = (1 - tokens) / rate
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'time', tostring(now))
+redis.call('EXPIRE', KEYS[1], math.ceil(burst / rate) + 1)
+return {allowed, tostring(wait)}
+`)
+
+// Allow takes a token from the bucket of the key, it returns a resource exhausted error
+// with the time to wait if the bucket is empty.
+// Calls are allowed if Redis fails, so the limiter doesn't make the APIs unavailable.
+func (l *Limiter) Allow(ctx context.Context, k Key) error {
+	limit := l.limit(k.Method)
+	if limit.Rate <= 0 || k.User == "" {
+		return nil
+	}
+
+	res, err := takeScript.Run(ctx, l.redis, []string{l.key(k)}, limit.Rate, max(limit.Burst, 1)).Slice()
+	if err != nil {
+		slog.ErrorContext(ctx, "ratelimit: take token failed", "method", k.Method, "error", err)
+		return nil
+	}
+
+	if allowed, _ := res[0].(int64); allowed == 1 {
+		return nil
+	}
+
+	wait, _ := res[1].(string)
+	seconds, err := strconv.ParseFloat(wait, 64)
+	if err != nil {
+		seconds = 1 / limit.Rate
+	}
+
+	rejectedTotal.WithLabelValues(k.Method).Inc()
+
+	return errors.New(errors.CodeResourceExhausted,
+		errors.WithMessagef("too many calls to %s, retry later", k.Method),
+		errors.WithReason(errors.ReasonRateLimited, "method", k.Method),
+		errors.WithRetryAfter(time.Duration(math.Ceil(seconds*1000))*time.Millisecond),
+	)
+}
+
+func (l *Limiter) limit(method string) Limit {
+	ls := l.limits.Load()
+	if limit, ok := ls.methods[strings.ToLower(method)]; ok {
+		return limit
+	}
+
+	return ls.def
+}
+
+func (l *Limiter) key(k Key) string {
+	key := fmt.Sprintf("%s:%s", l.prefix, k.Method)
+	if k.User != "" {
+		key += ":user:" + k.User
+	}
+	if k.Session != "" {
+		key += ":session:" + k.Session
+	}
+
+	return key
+}
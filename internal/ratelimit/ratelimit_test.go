@@ -0,0 +1,112 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/ratelimit"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	type call struct {
+		key     ratelimit.Key
+		allowed bool
+	}
+
+	tests := map[string]struct {
+		calls []call
+	}{
+		"burst then reject": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s1"}, allowed: true},
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s1"}, allowed: true},
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s1"}, allowed: false},
+			},
+		},
+		"buckets by user and session": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s1"}, allowed: true},
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s1"}, allowed: true},
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u2", Session: "s1"}, allowed: true},
+				{key: ratelimit.Key{Method: "SubmitAnswer", User: "u1", Session: "s2"}, allowed: true},
+			},
+		},
+		"method names are case-insensitive": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "submitanswer", User: "u1"}, allowed: true},
+				{key: ratelimit.Key{Method: "submitanswer", User: "u1"}, allowed: true},
+				{key: ratelimit.Key{Method: "submitanswer", User: "u1"}, allowed: false},
+			},
+		},
+		"default limit": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "GetLeaderboard", User: "u1"}, allowed: true},
+				{key: ratelimit.Key{Method: "GetLeaderboard", User: "u1"}, allowed: false},
+			},
+		},
+		"unlimited": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "CreateSession", User: "u1"}, allowed: true},
+				{key: ratelimit.Key{Method: "CreateSession", User: "u1"}, allowed: true},
+				{key: ratelimit.Key{Method: "CreateSession", User: "u1"}, allowed: true},
+			},
+		},
+		"public": {
+			calls: []call{
+				{key: ratelimit.Key{Method: "Check"}, allowed: true},
+				{key: ratelimit.Key{Method: "Check"}, allowed: true},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			l := ratelimit.NewLimiter(ratelimit.Config{
+				Redis:   redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()}),
+				Prefix:  "test",
+				Default: ratelimit.Limit{Rate: 0.1, Burst: 1},
+				Methods: map[string]ratelimit.Limit{
+					"SubmitAnswer":  {Rate: 0.5, Burst: 2},
+					"CreateSession": {},
+				},
+			})
+
+			for _, c := range tt.calls {
+				err := l.Allow(context.Background(), c.key)
+				if c.allowed {
+					require.NoError(t, err)
+					continue
+				}
+
+				e := errors.Convert(err)
+				require.Equal(t, errors.CodeResourceExhausted, e.Code)
+				require.Greater(t, e.RetryAfter, 0.0)
+			}
+		})
+	}
+}
+
+func TestLimiter_SetLimits(t *testing.T) {
+	l := ratelimit.NewLimiter(ratelimit.Config{
+		Redis:   redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()}),
+		Prefix:  "test",
+		Default: ratelimit.Limit{Rate: 0.1, Burst: 1},
+	})
+
+	k := ratelimit.Key{Method: "SubmitAnswer", User: "u1"}
+	require.NoError(t, l.Allow(context.Background(), k))
+	require.Error(t, l.Allow(context.Background(), k))
+
+	// Unlimited by the new method limit.
+	l.SetLimits(ratelimit.Limit{Rate: 0.1, Burst: 1}, map[string]ratelimit.Limit{"SubmitAnswer": {}})
+	require.NoError(t, l.Allow(context.Background(), k))
+	require.NoError(t, l.Allow(context.Background(), k))
+}
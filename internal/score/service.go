@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	stderrors "errors"
+	"log/slog"
 	"math/big"
 	"time"
 
@@ -64,9 +65,16 @@ func (s *Service) SubmitAnswer(ctx context.Context, req SubmitAnswerRequest) (*S
 		UpdateTime: req.SubmitTime,
 	}
 
-	s.eb.Publish(ctx, domain.EventScoreUpdated{
+	// The score is already saved, so the failure of publishing event should not fail the request.
+	if err := s.eb.Publish(ctx, domain.EventScoreUpdated{
 		Score: dto,
-	})
+	}); err != nil {
+		slog.ErrorContext(ctx, "score: publish score updated failed",
+			"session", req.SessionID,
+			"username", req.Username,
+			"error", err,
+		)
+	}
 
 	return &SubmitAnswerResponse{
 		Score:      score,
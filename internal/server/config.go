@@ -0,0 +1,263 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/victornm/equiz/internal/api"
+)
+
+// PostgresConfig is the config of a Postgres connection pool.
+type PostgresConfig struct {
+	Addr string
+	User string
+	Pass string
+	Name string
+	// SSLMode is the libpq sslmode, defaults to prefer.
+	SSLMode string
+	// SSLRootCert is the file of the CA certificates verifying the server with the verify-ca and verify-full modes.
+	SSLRootCert string
+	// SSLCert and SSLKey are the files of the client certificate and its key.
+	SSLCert string
+	SSLKey  string
+	// MaxConns and MinConns are the sizes of the pool, the zero values are pgxpool's defaults.
+	MaxConns int32
+	MinConns int32
+	// StatementTimeout aborts the statements running longer, zero means no timeout.
+	StatementTimeout time.Duration
+}
+
+// RedisConfig is the config of a Redis client, of Sentinel with MasterName or of Cluster with multiple Addrs.
+type RedisConfig struct {
+	Addrs []string
+	// MasterName is the name of the master monitored by the Sentinels.
+	MasterName string
+	// User and Pass are the ACL user and its password, the default user if User is empty.
+	User string
+	Pass string
+	// Sentinel is the ACL user of the Sentinels, if they require authentication.
+	Sentinel struct {
+		User string
+		Pass string
+	}
+	// DB is the database index, it must be 0 with Redis Cluster.
+	DB  int
+	TLS struct {
+		Enabled bool
+		// CACert is the file of the CA certificates verifying the server, the system ones if empty.
+		CACert string
+		// Cert and Key are the files of the client certificate and its key.
+		Cert       string
+		Key        string
+		ServerName string
+	}
+	// PoolSize and MinIdleConns are the sizes of the pool of each node, the zero values are go-redis's defaults.
+	PoolSize     int
+	MinIdleConns int
+	// The timeouts of dialing, reading and writing, the zero values are go-redis's defaults.
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// UniversalOptions returns the options of the client, the certificate files are read.
+func (c RedisConfig) UniversalOptions() (*redis.UniversalOptions, error) {
+	o := &redis.UniversalOptions{
+		Addrs:            c.Addrs,
+		MasterName:       c.MasterName,
+		Username:         c.User,
+		Password:         c.Pass,
+		SentinelUsername: c.Sentinel.User,
+		SentinelPassword: c.Sentinel.Pass,
+		DB:               c.DB,
+		PoolSize:         c.PoolSize,
+		MinIdleConns:     c.MinIdleConns,
+		DialTimeout:      c.DialTimeout,
+		ReadTimeout:      c.ReadTimeout,
+		WriteTimeout:     c.WriteTimeout,
+	}
+
+	if !c.TLS.Enabled {
+		return o, nil
+	}
+
+	o.TLSConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.TLS.ServerName,
+	}
+
+	if c.TLS.CACert != "" {
+		b, err := os.ReadFile(c.TLS.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("read CA certificates: %w", err)
+		}
+
+		o.TLSConfig.RootCAs = x509.NewCertPool()
+		if !o.TLSConfig.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no CA certificates in %s", c.TLS.CACert)
+		}
+	}
+
+	if c.TLS.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLS.Cert, c.TLS.Key)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		o.TLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return o, nil
+}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// DSN returns the URL of the database, the user, password and options are escaped.
+func (c PostgresConfig) DSN() string {
+	q := url.Values{}
+	for k, v := range map[string]string{
+		"sslmode":     c.SSLMode,
+		"sslrootcert": c.SSLRootCert,
+		"sslcert":     c.SSLCert,
+		"sslkey":      c.SSLKey,
+	} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Pass),
+		Host:     c.Addr,
+		Path:     "/" + c.Name,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
+
+func (c PostgresConfig) poolConfig() (*pgxpool.Config, error) {
+	cc, err := pgxpool.ParseConfig(c.DSN())
+	if err != nil {
+		// pgx redacts the password in the errors.
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	if c.MaxConns > 0 {
+		cc.MaxConns = c.MaxConns
+	}
+	if c.MinConns > 0 {
+		cc.MinConns = c.MinConns
+	}
+	if c.StatementTimeout > 0 {
+		cc.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
+	}
+
+	return cc, nil
+}
+
+// DefaultConfig returns the config with the default values, the loaded config overrides them.
+func DefaultConfig() Config {
+	var c Config
+
+	c.Log.Level = "info"
+
+	c.HTTP.Port = 8080
+	c.GRPC.Port = 8081
+
+	c.Leaderboard.PublishInterval = 200 * time.Millisecond
+
+	c.Redis.Pubsub.Fanout = string(api.FanoutUser)
+	c.Redis.Pubsub.Retention = api.Retention{MaxLen: 1000, TTL: 24 * time.Hour}
+	c.Redis.Pubsub.Leaderboard = api.LeaderboardUpdates{SnapshotInterval: 100, TopN: 10}
+
+	c.Webhook.MaxAttempts = 5
+	c.Webhook.Backoff = time.Second
+	c.Webhook.Timeout = 10 * time.Second
+	c.Webhook.QueueSize = 1000
+
+	return c
+}
+
+// Validate returns the errors of all invalid values, named by their keys.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+
+	validPort := func(key string, port int32) {
+		check(port > 0 && port <= 65535, key, "must be in 1-65535, got %d", port)
+	}
+	validPort("http.port", c.HTTP.Port)
+	validPort("grpc.port", c.GRPC.Port)
+	check(c.HTTP.Port != c.GRPC.Port, "grpc.port", "must be different from http.port")
+
+	check(c.Auth.Secret != "" || c.Auth.JWKS != "", "auth", "secret or jwks is required")
+
+	check(c.Leaderboard.PublishInterval > 0, "leaderboard.publishinterval", "must be positive")
+
+	check(c.RateLimit.Prefix != "", "ratelimit.prefix", "is required")
+	check(c.RateLimit.Default.Rate >= 0 && c.RateLimit.Default.Burst >= 0, "ratelimit.default", "must not be negative")
+	for method, l := range c.RateLimit.Methods {
+		check(l.Rate >= 0 && l.Burst >= 0, "ratelimit.methods."+method, "must not be negative")
+	}
+
+	validRedis := func(key string, c RedisConfig) {
+		check(len(c.Addrs) > 0 && !slices.Contains(c.Addrs, ""), key+".addrs", "must be non-empty addresses")
+		check(c.DB >= 0, key+".db", "must not be negative")
+		check(c.DB == 0 || c.MasterName != "" || len(c.Addrs) <= 1, key+".db", "must be 0 with Redis Cluster")
+		check(c.TLS.Enabled || (c.TLS.CACert == "" && c.TLS.Cert == ""), key+".tls.enabled", "must be set with the certificates")
+		check((c.TLS.Cert == "") == (c.TLS.Key == ""), key+".tls.cert", "must be set with tls.key")
+		check(c.PoolSize >= 0 && c.MinIdleConns >= 0, key, "must not be negative")
+		check(c.DialTimeout >= 0 && c.ReadTimeout >= 0 && c.WriteTimeout >= 0, key, "must not be negative")
+	}
+	validRedis("redis.leaderboard", c.Redis.Leaderboard.RedisConfig)
+	check(c.Redis.Leaderboard.Prefix != "", "redis.leaderboard.prefix", "is required")
+	validRedis("redis.pubsub", c.Redis.Pubsub.RedisConfig)
+	check(c.Redis.Pubsub.Prefix != "", "redis.pubsub.prefix", "is required")
+	check(slices.Contains([]api.FanoutMode{api.FanoutSession, api.FanoutUser}, api.FanoutMode(c.Redis.Pubsub.Fanout)),
+		"redis.pubsub.fanout", "must be %s or %s, got %q", api.FanoutSession, api.FanoutUser, c.Redis.Pubsub.Fanout)
+	check(c.Redis.Pubsub.Retention.MaxLen >= 0 && c.Redis.Pubsub.Retention.TTL >= 0, "redis.pubsub.retention", "must not be negative")
+	check(c.Redis.Pubsub.Leaderboard.SnapshotInterval >= 0 && c.Redis.Pubsub.Leaderboard.TopN >= 0,
+		"redis.pubsub.leaderboard", "must not be negative")
+
+	check(c.Webhook.MaxAttempts >= 0 && c.Webhook.Backoff >= 0 && c.Webhook.Timeout >= 0 && c.Webhook.QueueSize >= 0, "webhook", "must not be negative")
+	for i, sub := range c.Webhook.Subscriptions {
+		key := fmt.Sprintf("webhook.subscriptions[%d]", i)
+
+		u, err := url.Parse(sub.URL)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", key+".url", "must be an HTTP URL, got %q", sub.URL)
+		check(sub.Secret != "", key+".secret", "is required")
+	}
+
+	validPostgres := func(key string, c PostgresConfig) {
+		check(c.Addr != "", key+".addr", "is required")
+		check(c.User != "", key+".user", "is required")
+		check(c.Name != "", key+".name", "is required")
+		check(c.SSLMode == "" || slices.Contains(sslModes, c.SSLMode), key+".sslmode", "must be one of %v, got %q", sslModes, c.SSLMode)
+		check((c.SSLCert == "") == (c.SSLKey == ""), key+".sslcert", "must be set with sslkey")
+		check(c.MaxConns >= 0 && c.MinConns >= 0 && c.StatementTimeout >= 0, key, "must not be negative")
+		check(c.MaxConns == 0 || c.MinConns <= c.MaxConns, key+".minconns", "must not be greater than maxconns")
+	}
+	validPostgres("postgres.session", c.Postgres.Session)
+	validPostgres("postgres.score", c.Postgres.Score)
+
+	return stderrors.Join(errs...)
+}
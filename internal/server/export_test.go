@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/victornm/equiz/internal/session"
+)
+
+// NewTestServer creates a server with the given infrastructure instead of connecting to it,
+// the sessions are read from db.
+func NewTestServer(c Config, r redis.UniversalClient, pool *pgxpool.Pool, db session.DB) (*Server, error) {
+	s, err := newServer(c)
+	if err != nil {
+		return nil, err
+	}
+
+	s.infra.redis.leaderboard, s.infra.redis.pubsub = r, r
+	s.infra.postgres.session, s.infra.postgres.score = pool, pool
+
+	s.initService()
+	s.service.session = session.NewService(session.Config{DB: db, EventBus: s.eb})
+
+	return s, s.initAPI()
+}
+
+// Serve serves on the listeners until the server is shut down.
+func (s *Server) Serve(grpcLis, httpLis net.Listener) {
+	s.serve(grpcLis, httpLis)
+}
+
+// ReportNotServing reports the server is not serving like Shutdown, without stopping it.
+func (s *Server) ReportNotServing() {
+	s.reportNotServing()
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	readinessTimeout  = 3 * time.Second
+	readinessInterval = 5 * time.Second
+)
+
+// healthz reports the server is alive.
+func (s *Server) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readyz reports whether the server isn't shutting down, and Postgres and Redis are reachable.
+func (s *Server) readyz(c *gin.Context) {
+	if s.shutdown.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting down"})
+		return
+	}
+
+	checks, ready := s.checkReadiness(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready", "checks": checks})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "checks": checks})
+}
+
+// checkReadiness pings the dependencies concurrently, the errors are logged as they can reveal the infrastructure.
+func (s *Server) checkReadiness(ctx context.Context) (map[string]string, bool) {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	pings := map[string]func(context.Context) error{
+		"postgres.session":  s.infra.postgres.session.Ping,
+		"postgres.score":    s.infra.postgres.score.Ping,
+		"redis.leaderboard": func(ctx context.Context) error { return s.infra.redis.leaderboard.Ping(ctx).Err() },
+		"redis.pubsub":      func(ctx context.Context) error { return s.infra.redis.pubsub.Ping(ctx).Err() },
+	}
+
+	var (
+		mu     sync.Mutex
+		eg     errgroup.Group
+		checks = make(map[string]string, len(pings))
+		ready  = true
+	)
+
+	for name, ping := range pings {
+		eg.Go(func() error {
+			err := ping(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			checks[name] = "ok"
+			if err != nil {
+				slog.WarnContext(ctx, "server: readiness check failed", "component", name, "error", err)
+				checks[name] = "unavailable"
+				ready = false
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return checks, ready
+}
+
+// watchReadiness updates the serving status of the gRPC health service by the readiness, until the server is shut down.
+func (s *Server) watchReadiness(ctx context.Context) {
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if _, ready := s.checkReadiness(ctx); !ready {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		// The status can't be changed after the health service is shut down.
+		s.health.SetServingStatus("", status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
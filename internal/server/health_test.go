@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type readyzResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func TestServer_Readyz(t *testing.T) {
+	tests := map[string]struct {
+		redisDown  bool
+		wantChecks map[string]string
+	}{
+		// The Postgres of the test servers is unreachable.
+		"should not be ready if Postgres is down": {
+			wantChecks: map[string]string{
+				"postgres.session":  "unavailable",
+				"postgres.score":    "unavailable",
+				"redis.leaderboard": "ok",
+				"redis.pubsub":      "ok",
+			},
+		},
+		"should not be ready if Redis is down": {
+			redisDown: true,
+			wantChecks: map[string]string{
+				"postgres.session":  "unavailable",
+				"postgres.score":    "unavailable",
+				"redis.leaderboard": "unavailable",
+				"redis.pubsub":      "unavailable",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mr := miniredis.RunT(t)
+			ts := startServer(t, mr)
+			if tt.redisDown {
+				mr.Close()
+			}
+
+			resp, body := ts.readyz(t)
+			require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			require.Equal(t, readyzResponse{Status: "not ready", Checks: tt.wantChecks}, body)
+		})
+	}
+}
+
+func TestServer_ReportNotServing(t *testing.T) {
+	ts := startServer(t, miniredis.RunT(t))
+
+	conn, err := grpc.NewClient(ts.grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ts.ReportNotServing()
+
+	resp, body := ts.readyz(t)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, readyzResponse{Status: "shutting down"}, body)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, health.Status)
+}
+
+func (ts testServer) readyz(t *testing.T) (*http.Response, readyzResponse) {
+	t.Helper()
+
+	resp, err := http.Get(ts.httpURL + "/readyz")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	var body readyzResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	return resp, body
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/victornm/equiz/internal/config"
+)
+
+// reloadable are the keys of the settings applied to the running server, with their nested keys.
+var reloadable = []string{
+	"log.level",
+	"leaderboard.publishinterval",
+	"ratelimit.default",
+	"ratelimit.methods",
+}
+
+// ReloadChanges returns the keys changed by the new config, or an error naming the ones not reloadable.
+func (c Config) ReloadChanges(n Config) ([]string, error) {
+	changed := config.Diff(c, n)
+
+	var rejected []string
+	for _, key := range changed {
+		if !isReloadable(key) {
+			rejected = append(rejected, key)
+		}
+	}
+
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("not reloadable settings changed, restart to apply them: %s", strings.Join(rejected, ", "))
+	}
+
+	return changed, nil
+}
+
+func isReloadable(key string) bool {
+	for _, k := range reloadable {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reload applies the new config to the running server, unless a setting which isn't reloadable changed.
+func (s *Server) Reload(c Config) error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	changed, err := s.c.ReloadChanges(c)
+	if err != nil {
+		slog.Error("server: reload config rejected", "error", err)
+		return fmt.Errorf("server: reload config: %w", err)
+	}
+
+	if len(changed) == 0 {
+		slog.Info("server: config not changed")
+		return nil
+	}
+
+	// The config is validated when loading, so applying it doesn't fail halfway.
+	if err := s.setLogLevel(c.Log.Level); err != nil {
+		return fmt.Errorf("server: reload config: %w", err)
+	}
+	s.service.leaderboard.SetPublishInterval(c.Leaderboard.PublishInterval)
+	s.limiter.SetLimits(c.RateLimit.Default, c.RateLimit.Methods)
+
+	s.c = c
+	slog.Info("server: config reloaded", "changed", changed)
+
+	return nil
+}
+
+func (s *Server) setLogLevel(level string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("log level: %w", err)
+	}
+
+	// The level of the default logger, which all packages log to.
+	slog.SetLogLoggerLevel(l)
+
+	return nil
+}
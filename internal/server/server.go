@@ -2,11 +2,13 @@ package server
 
 import (
 	"context"
-	"errors"
+	stderrors "errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-contrib/pprof"
@@ -16,16 +18,30 @@ import (
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/victornm/equiz/internal/api"
+	"github.com/victornm/equiz/internal/auth"
+	"github.com/victornm/equiz/internal/errors"
 	"github.com/victornm/equiz/internal/event"
 	"github.com/victornm/equiz/internal/leaderboard"
+	"github.com/victornm/equiz/internal/ratelimit"
 	"github.com/victornm/equiz/internal/score"
 	"github.com/victornm/equiz/internal/session"
 	"github.com/victornm/equiz/internal/telemetry"
+	"github.com/victornm/equiz/internal/webhook"
 )
 
+const shutdownTimeout = 5 * time.Second
+
 type Config struct {
+	Log struct {
+		// Level is the minimum level of the logs: debug, info, warn or error.
+		Level string
+	}
+
 	HTTP struct {
 		Port int32
 	}
@@ -34,34 +50,48 @@ type Config struct {
 		Port int32
 	}
 
+	Auth auth.Config
+
+	RateLimit struct {
+		Prefix  string
+		Default ratelimit.Limit
+		Methods map[string]ratelimit.Limit
+	}
+
+	Leaderboard struct {
+		// PublishInterval is the minimum interval between the published leaderboards of a session.
+		PublishInterval time.Duration
+	}
+
 	Redis struct {
 		Leaderboard struct {
-			Addrs  []string
-			Pass   string
-			Prefix string
+			RedisConfig `mapstructure:",squash"`
+			Prefix      string
 		}
 
 		Pubsub struct {
-			Addrs  []string
-			Pass   string
-			Prefix string
+			RedisConfig `mapstructure:",squash"`
+			Prefix      string
+			// Fanout is the fan-out mode of the notifications: session or user.
+			Fanout string
+			// Retention of the recent notifications of each session, for the reconnecting clients.
+			Retention api.Retention
+			// Leaderboard is how the leaderboard updates are published: full leaderboards or deltas.
+			Leaderboard api.LeaderboardUpdates
 		}
 	}
 
-	Postgres struct {
-		Session struct {
-			Addr string
-			User string
-			Pass string
-			Name string
-		}
+	Webhook struct {
+		MaxAttempts   int
+		Backoff       time.Duration
+		Timeout       time.Duration
+		QueueSize     int
+		Subscriptions []webhook.Subscription
+	}
 
-		Score struct {
-			Addr string
-			User string
-			Pass string
-			Name string
-		}
+	Postgres struct {
+		Session PostgresConfig
+		Score   PostgresConfig
 	}
 }
 
@@ -86,25 +116,73 @@ type Server struct {
 		session     *session.Service
 		score       *score.Service
 		leaderboard *leaderboard.Service
+		webhook     *webhook.Service
 	}
 
-	http *http.Server
-	grpc *grpc.Server
+	limiter *ratelimit.Limiter
+	api     *api.API
+
+	http   *http.Server
+	grpc   *grpc.Server
+	health *health.Server
+
+	// reloadMu serializes the reloads of the config.
+	reloadMu sync.Mutex
+
+	// shutdown is set when the server starts shutting down.
+	shutdown atomic.Bool
+	// readiness is the context of watching the readiness, it is created by Init.
+	readiness     context.Context
+	stopReadiness context.CancelFunc
+	// streams is the base context of the streams, it is canceled on shutdown so the streams end.
+	streams     context.Context
+	stopStreams context.CancelFunc
+	// webSocketsClosed is closed when the WebSocket connections are closed on shutdown.
+	webSocketsClosed chan struct{}
+	closeWebSockets  sync.Once
 }
 
 func Init(c Config) (*Server, error) {
-	s := &Server{c: c}
-
-	// TODO: add telemetry
-
-	s.eb = event.NewBus()
+	s, err := newServer(c)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := s.initInfra(); err != nil {
 		return nil, fmt.Errorf("server: init infra: %w", err)
 	}
 
 	s.initService()
-	s.initAPI()
+	if err := s.initAPI(); err != nil {
+		return nil, fmt.Errorf("server: init API: %w", err)
+	}
+
+	return s, nil
+}
+
+// newServer creates a server without its infrastructure and services.
+func newServer(c Config) (*Server, error) {
+	s := &Server{c: c}
+	s.readiness, s.stopReadiness = context.WithCancel(context.Background())
+	s.streams, s.stopStreams = context.WithCancel(context.Background())
+	s.webSocketsClosed = make(chan struct{})
+
+	if err := s.setLogLevel(c.Log.Level); err != nil {
+		return nil, fmt.Errorf("server: %w", err)
+	}
+
+	telemetry.InitTracing()
+
+	s.eb = event.NewBus(
+		event.WithSource("equiz"),
+		event.WithMiddleware(
+			event.Recover(),
+			event.Trace(),
+			event.Metrics(),
+			event.Log(slog.Default()),
+		),
+	)
+
 	return s, nil
 }
 
@@ -121,14 +199,16 @@ func (s *Server) initInfra() error {
 }
 
 func (s *Server) initRedis() error {
-	connect := func(addrs []string, pass string) (redis.UniversalClient, error) {
+	connect := func(c RedisConfig) (redis.UniversalClient, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		r := redis.NewUniversalClient(&redis.UniversalOptions{
-			Addrs:    addrs,
-			Password: pass,
-		})
+		o, err := c.UniversalOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		r := redis.NewUniversalClient(o)
 
 		if err := telemetry.MonitorRedis(r); err != nil {
 			return nil, err
@@ -142,12 +222,12 @@ func (s *Server) initRedis() error {
 	}
 
 	var err error
-	s.infra.redis.leaderboard, err = connect(s.c.Redis.Leaderboard.Addrs, s.c.Redis.Leaderboard.Pass)
+	s.infra.redis.leaderboard, err = connect(s.c.Redis.Leaderboard.RedisConfig)
 	if err != nil {
 		return fmt.Errorf("leaderboard: %w", err)
 	}
 
-	s.infra.redis.pubsub, err = connect(s.c.Redis.Pubsub.Addrs, s.c.Redis.Pubsub.Pass)
+	s.infra.redis.pubsub, err = connect(s.c.Redis.Pubsub.RedisConfig)
 	if err != nil {
 		return fmt.Errorf("pubsub: %w", err)
 	}
@@ -156,11 +236,11 @@ func (s *Server) initRedis() error {
 }
 
 func (s *Server) initPostgres() (err error) {
-	connect := func(addr, user, pass, name string) (*pgxpool.Pool, error) {
+	connect := func(c PostgresConfig) (*pgxpool.Pool, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		cc, err := pgxpool.ParseConfig(fmt.Sprintf("postgres://%s:%s@%s/%s", user, pass, addr, name))
+		cc, err := c.poolConfig()
 		if err != nil {
 			return nil, err
 		}
@@ -177,12 +257,12 @@ func (s *Server) initPostgres() (err error) {
 		return db, nil
 	}
 
-	s.infra.postgres.session, err = connect(s.c.Postgres.Session.Addr, s.c.Postgres.Session.User, s.c.Postgres.Session.Pass, s.c.Postgres.Session.Name)
+	s.infra.postgres.session, err = connect(s.c.Postgres.Session)
 	if err != nil {
 		return fmt.Errorf("postgres: session: %w", err)
 	}
 
-	s.infra.postgres.score, err = connect(s.c.Postgres.Score.Addr, s.c.Postgres.Score.User, s.c.Postgres.Score.Pass, s.c.Postgres.Score.Name)
+	s.infra.postgres.score, err = connect(s.c.Postgres.Score)
 	if err != nil {
 		return fmt.Errorf("postgres: score: %w", err)
 	}
@@ -197,7 +277,8 @@ func (s *Server) initService() {
 	})
 
 	s.service.session = session.NewService(session.Config{
-		DB: s.infra.postgres.session,
+		DB:       s.infra.postgres.session,
+		EventBus: s.eb,
 	})
 
 	s.service.leaderboard = leaderboard.NewService(leaderboard.Config{
@@ -205,73 +286,217 @@ func (s *Server) initService() {
 		Score:    s.service.score,
 		Redis:    s.infra.redis.leaderboard,
 		Prefix:   s.c.Redis.Leaderboard.Prefix,
+
+		PublishInterval: s.c.Leaderboard.PublishInterval,
+	})
+
+	s.service.webhook = webhook.NewService(webhook.Config{
+		EventBus:      s.eb,
+		Sessions:      s.service.session,
+		Leaderboards:  s.service.leaderboard,
+		Log:           webhook.NewPostgresLog(s.infra.postgres.session),
+		Subscriptions: s.c.Webhook.Subscriptions,
+		MaxAttempts:   s.c.Webhook.MaxAttempts,
+		Backoff:       s.c.Webhook.Backoff,
+		Timeout:       s.c.Webhook.Timeout,
+		QueueSize:     s.c.Webhook.QueueSize,
 	})
 }
 
-func (s *Server) initAPI() {
+func (s *Server) initAPI() error {
+	authenticator, err := auth.NewAuthenticator(s.c.Auth)
+	if err != nil {
+		return err
+	}
+
+	s.limiter = ratelimit.NewLimiter(ratelimit.Config{
+		Redis:   s.infra.redis.pubsub,
+		Prefix:  s.c.RateLimit.Prefix,
+		Default: s.c.RateLimit.Default,
+		Methods: s.c.RateLimit.Methods,
+	})
+
 	e := gin.New()
 	e.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	e.GET("/healthz", s.healthz)
+	e.GET("/readyz", s.readyz)
 	pprof.Register(e, "/debug/pprof")
-	e.Use(gin.Recovery())
-
-	s.grpc = grpc.NewServer(telemetry.GRPCServerInterceptor())
-
-	api.New(api.Config{
-		GRPC:         s.grpc,
-		EventBus:     s.eb,
-		Session:      s.service.session,
-		Score:        s.service.score,
-		Leaderboard:  s.service.leaderboard,
-		Redis:        s.infra.redis.pubsub,
-		PubsubPrefix: s.c.Redis.Pubsub.Prefix,
+	e.Use(telemetry.Middleware(), gin.Recovery(), errors.Middleware())
+
+	s.grpc = grpc.NewServer(
+		s.endStreamsOnShutdown(),
+		telemetry.GRPCServerInterceptor(),
+		telemetry.GRPCServerStreamInterceptor(),
+		errors.GRPCServerInterceptor(),
+		errors.GRPCServerStreamInterceptor(),
+		auth.GRPCServerInterceptor(authenticator),
+		auth.GRPCServerStreamInterceptor(authenticator),
+		ratelimit.GRPCServerInterceptor(s.limiter),
+		ratelimit.GRPCServerStreamInterceptor(s.limiter),
+	)
+
+	s.health = health.NewServer()
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+
+	s.api = api.New(api.Config{
+		GRPC:               s.grpc,
+		HTTP:               e,
+		Auth:               authenticator,
+		RateLimit:          s.limiter,
+		EventBus:           s.eb,
+		Session:            s.service.session,
+		Score:              s.service.score,
+		Leaderboard:        s.service.leaderboard,
+		Redis:              s.infra.redis.pubsub,
+		PubsubPrefix:       s.c.Redis.Pubsub.Prefix,
+		Fanout:             api.FanoutMode(s.c.Redis.Pubsub.Fanout),
+		Retention:          s.c.Redis.Pubsub.Retention,
+		LeaderboardUpdates: s.c.Redis.Pubsub.Leaderboard,
 	})
 
 	s.http = &http.Server{
 		Addr:              fmt.Sprintf(":%d", s.c.HTTP.Port),
 		Handler:           e,
 		ReadHeaderTimeout: 60 * time.Second,
+		// The Server-Sent Events streams end when the base context is canceled on shutdown.
+		BaseContext: func(net.Listener) context.Context { return s.streams },
 	}
+	s.http.RegisterOnShutdown(func() {
+		s.closeWebSockets.Do(func() {
+			s.api.CloseWebSockets()
+			close(s.webSocketsClosed)
+		})
+	})
+
+	return nil
 }
 
 func (s *Server) Start() {
 	ctx := context.TODO()
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.c.GRPC.Port))
+	grpcLis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.c.GRPC.Port))
 	if err != nil {
 		slog.ErrorContext(ctx, "grpc server: listen failed", "error", err)
 		panic(err)
 	}
 
+	httpLis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.c.HTTP.Port))
+	if err != nil {
+		slog.ErrorContext(ctx, "http server: listen failed", "error", err)
+		panic(err)
+	}
+
+	s.serve(grpcLis, httpLis)
+}
+
+func (s *Server) serve(grpcLis, httpLis net.Listener) {
+	ctx := context.TODO()
+
+	go s.watchReadiness(s.readiness)
+
 	var eg errgroup.Group
 	eg.Go(func() error {
-		slog.InfoContext(ctx, fmt.Sprintf("server: gRPC listening on port %d", s.c.GRPC.Port))
-		return s.grpc.Serve(lis)
+		slog.InfoContext(ctx, fmt.Sprintf("server: gRPC listening on %s", grpcLis.Addr()))
+		return s.grpc.Serve(grpcLis)
 	})
 
 	eg.Go(func() error {
-		slog.InfoContext(ctx, fmt.Sprintf("server: HTTP listening on port %d", s.c.HTTP.Port))
-		if err := s.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.InfoContext(ctx, fmt.Sprintf("server: HTTP listening on %s", httpLis.Addr()))
+		if err := s.http.Serve(httpLis); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
 			return err
 		}
 		return nil
 	})
 
-	err = eg.Wait()
+	err := eg.Wait()
 	if err != nil {
 		slog.ErrorContext(ctx, "server: shutdown with error", "error", err)
 	}
 }
 
 func (s *Server) Shutdown() {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	s.reportNotServing()
+	s.stopServing()
+
+	// The event bus has its own timeout, the servers have stopped publishing events by then.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	s.grpc.GracefulStop()
+	if err := s.eb.Stop(ctx); err != nil {
+		slog.ErrorContext(ctx, "server: stop event bus failed", "error", err)
+	}
+
+	// The webhooks have their own timeout too, the handlers queueing the deliveries are flushed by then.
+	whCtx, whCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer whCancel()
+
+	if err := s.service.webhook.Stop(whCtx); err != nil {
+		slog.ErrorContext(whCtx, "server: stop webhook failed", "error", err)
+	}
+
+	slog.InfoContext(ctx, "server: shutdown completed")
+}
+
+// reportNotServing reports the server is not serving, so the load balancers stop sending new requests.
+func (s *Server) reportNotServing() {
+	s.shutdown.Store(true)
+	s.stopReadiness()
+	s.health.Shutdown()
+}
+
+// stopServing ends the streams, then waits for the other requests until the timeout.
+func (s *Server) stopServing() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	s.stopStreams()
+	stopGRPC(ctx, s.grpc)
 	if err := s.http.Shutdown(ctx); err != nil {
 		slog.ErrorContext(ctx, "server: shutdown HTTP failed", "error", err)
 	}
 
-	s.eb.Stop()
+	// The answers submitted over the WebSockets are handled before the event bus stops.
+	select {
+	case <-s.webSocketsClosed:
+	case <-ctx.Done():
+	}
+}
 
-	slog.InfoContext(ctx, "server: shutdown completed")
+// stopGRPC stops the server gracefully, the remaining RPCs are canceled when the context is done.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+		<-done
+	}
+}
+
+// endStreamsOnShutdown cancels the contexts of the streams when the server shuts down.
+func (s *Server) endStreamsOnShutdown() grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+
+		stop := context.AfterFunc(s.streams, cancel)
+		defer stop()
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
 }
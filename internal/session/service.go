@@ -4,22 +4,33 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
 	"github.com/victornm/equiz/internal/event"
 )
 
+// DB is the database of the sessions, it is implemented by *pgxpool.Pool.
+type DB interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type Config struct {
-	DB       *pgxpool.Pool
+	DB       DB
 	EventBus *event.Bus
 }
 
 type Service struct {
-	db *pgxpool.Pool
+	db DB
 	eb *event.Bus
 }
 
@@ -88,21 +99,226 @@ func (s *Service) insertSession(ctx context.Context, ss *domain.Session) (err er
 	return tx.Commit(ctx)
 }
 
+type GetSessionRequest struct {
+	SessionID string
+}
+
+// GetSession returns a quiz session with its questions and participants.
+func (s *Service) GetSession(ctx context.Context, req GetSessionRequest) (*domain.Session, error) {
+	if _, err := uuid.Parse(req.SessionID); err != nil {
+		return nil, errors.InvalidField("session_id", "must be a UUID: %s", req.SessionID)
+	}
+
+	ss := &domain.Session{SessionID: req.SessionID}
+
+	const (
+		selSessionStmt   = `SELECT quiz_master FROM sessions WHERE session_id = $1;`
+		selQuestionsStmt = `SELECT question_id FROM sessions_questions WHERE session_id = $1;`
+		selUsersStmt     = `SELECT username FROM sessions_users WHERE session_id = $1 ORDER BY create_time;`
+	)
+
+	err := s.db.QueryRow(ctx, selSessionStmt, req.SessionID).Scan(&ss.QuizMaster)
+	if stderrors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New(errors.CodeNotFound,
+			errors.WithMessagef("session not found: session=%s", req.SessionID),
+			errors.WithReason(errors.ReasonSessionNotFound, "session_id", req.SessionID),
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select session: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx, selQuestionsStmt, req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("select questions: %w", err)
+	}
+
+	ss.QuestionIDs, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("select questions: %w", err)
+	}
+
+	rows, err = s.db.Query(ctx, selUsersStmt, req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("select participants: %w", err)
+	}
+
+	ss.Participants, err = pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("select participants: %w", err)
+	}
+
+	return ss, nil
+}
+
+type JoinSessionRequest struct {
+	SessionID string
+	Username  string
+}
+
+// JoinSession adds a participant to a quiz session, joining a joined session has no effect.
+func (s *Service) JoinSession(ctx context.Context, req JoinSessionRequest) (*domain.Session, error) {
+	ss, err := s.GetSession(ctx, GetSessionRequest{SessionID: req.SessionID})
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(ss.Participants, req.Username) {
+		return ss, nil
+	}
+
+	const insStmt = `INSERT INTO sessions_users (session_id, username, create_time) VALUES ($1, $2, NOW()) ON CONFLICT DO NOTHING;`
+
+	if _, err := s.db.Exec(ctx, insStmt, req.SessionID, req.Username); err != nil {
+		return nil, fmt.Errorf("insert participant: %w", err)
+	}
+	ss.Participants = append(ss.Participants, req.Username)
+
+	return ss, nil
+}
+
+type StartSessionRequest struct {
+	SessionID string
+}
+
+// StartSession starts a quiz session, starting a started session has no effect.
+// session.started is only published by the call which starts the session.
+func (s *Service) StartSession(ctx context.Context, req StartSessionRequest) (*domain.Session, error) {
+	const updStmt = `UPDATE sessions SET start_time = NOW() WHERE session_id = $1 AND start_time IS NULL;`
+
+	ss, updated, err := s.updateSession(ctx, req.SessionID, updStmt)
+	if err != nil || !updated {
+		return ss, err
+	}
+
+	if err := s.eb.Publish(ctx, domain.EventSessionStarted{
+		Session: *ss,
+	}); err != nil {
+		return nil, fmt.Errorf("publish session started: %w", err)
+	}
+
+	return ss, nil
+}
+
 type EndSessionRequest struct {
 	SessionID string
 }
 
+// EndSession ends a quiz session, ending an ended session has no effect.
+// session.ended is only published by the call which ends the session.
 func (s *Service) EndSession(ctx context.Context, req EndSessionRequest) (*domain.Session, error) {
-	// Assuming this return is valid
-	ss := domain.Session{
-		SessionID: req.SessionID,
+	const updStmt = `UPDATE sessions SET end_time = NOW() WHERE session_id = $1 AND end_time IS NULL;`
+
+	ss, updated, err := s.updateSession(ctx, req.SessionID, updStmt)
+	if err != nil || !updated {
+		return ss, err
+	}
+
+	if err := s.eb.Publish(ctx, domain.EventSessionEnded{
+		Session: *ss,
+	}); err != nil {
+		return nil, fmt.Errorf("publish session ended: %w", err)
+	}
+
+	return ss, nil
+}
+
+// updateSession returns the session, and whether the statement updated it.
+func (s *Service) updateSession(ctx context.Context, session, stmt string) (*domain.Session, bool, error) {
+	ss, err := s.GetSession(ctx, GetSessionRequest{SessionID: session})
+	if err != nil {
+		return nil, false, err
 	}
 
-	s.eb.Publish(ctx, domain.EventSessionEnded{
-		Session: ss,
-	})
+	tag, err := s.db.Exec(ctx, stmt, session)
+	if err != nil {
+		return nil, false, fmt.Errorf("update session: %w", err)
+	}
+
+	return ss, tag.RowsAffected() == 1, nil
+}
+
+type StartQuestionRequest struct {
+	SessionID  string
+	QuestionID string
+}
+
+// StartQuestion starts a question of a quiz session, starting a started question has no effect.
+// question.started is only published by the call which starts the question.
+func (s *Service) StartQuestion(ctx context.Context, req StartQuestionRequest) error {
+	const updStmt = `UPDATE sessions_questions SET start_time = NOW() WHERE session_id = $1 AND question_id = $2 AND start_time IS NULL;`
+
+	updated, err := s.updateQuestion(ctx, req.SessionID, req.QuestionID, updStmt)
+	if err != nil || !updated {
+		return err
+	}
+
+	if err := s.eb.Publish(ctx, domain.EventQuestionStarted{
+		SessionID:  req.SessionID,
+		QuestionID: req.QuestionID,
+	}); err != nil {
+		return fmt.Errorf("publish question started: %w", err)
+	}
+
+	return nil
+}
+
+type EndQuestionRequest struct {
+	SessionID  string
+	QuestionID string
+}
+
+// EndQuestion ends a question of a quiz session, ending an ended question has no effect.
+// question.ended is only published by the call which ends the question.
+func (s *Service) EndQuestion(ctx context.Context, req EndQuestionRequest) error {
+	const updStmt = `UPDATE sessions_questions SET end_time = NOW() WHERE session_id = $1 AND question_id = $2 AND end_time IS NULL;`
+
+	updated, err := s.updateQuestion(ctx, req.SessionID, req.QuestionID, updStmt)
+	if err != nil || !updated {
+		return err
+	}
+
+	if err := s.eb.Publish(ctx, domain.EventQuestionEnded{
+		SessionID:  req.SessionID,
+		QuestionID: req.QuestionID,
+	}); err != nil {
+		return fmt.Errorf("publish question ended: %w", err)
+	}
+
+	return nil
+}
+
+// updateQuestion returns whether the statement updated the question.
+// The question is checked to exist when it isn't updated, it may already be started or ended.
+func (s *Service) updateQuestion(ctx context.Context, session, question, stmt string) (bool, error) {
+	if _, err := uuid.Parse(session); err != nil {
+		return false, errors.InvalidField("session_id", "must be a UUID: %s", session)
+	}
+
+	tag, err := s.db.Exec(ctx, stmt, session, question)
+	if err != nil {
+		return false, fmt.Errorf("update question: %w", err)
+	}
+
+	if tag.RowsAffected() == 1 {
+		return true, nil
+	}
+
+	const selStmt = `SELECT EXISTS (SELECT 1 FROM sessions_questions WHERE session_id = $1 AND question_id = $2);`
+
+	var exists bool
+	if err := s.db.QueryRow(ctx, selStmt, session, question).Scan(&exists); err != nil {
+		return false, fmt.Errorf("select question: %w", err)
+	}
+
+	if !exists {
+		return false, errors.New(errors.CodeNotFound,
+			errors.WithMessagef("question not found: session=%s question=%s", session, question),
+			errors.WithReason(errors.ReasonQuestionNotFound, "session_id", session, "question_id", question),
+		)
+	}
 
-	return &ss, nil
+	return false, nil
 }
 
 type ValidateSubmissionRequest struct {
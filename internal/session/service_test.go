@@ -0,0 +1,312 @@
+package session_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/event"
+	"github.com/victornm/equiz/internal/session"
+)
+
+const (
+	s1 = "0192a6b4-5d5e-7a3e-8b5e-6a0a3c1b2f01"
+	s2 = "0192a6b4-5d5e-7a3e-8b5e-6a0a3c1b2f02"
+)
+
+func TestService_Lifecycle(t *testing.T) {
+	tests := map[string]struct {
+		calls      func(ctx context.Context, s *session.Service) error
+		wantErr    bool
+		wantCode   errors.Code
+		wantEvents []string
+	}{
+		"start session should publish session.started": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				_, err := s.StartSession(ctx, session.StartSessionRequest{SessionID: s1})
+				return err
+			},
+			wantEvents: []string{domain.EventNameSessionStarted},
+		},
+
+		"start started session should not publish session.started again": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				for i := 0; i < 2; i++ {
+					if _, err := s.StartSession(ctx, session.StartSessionRequest{SessionID: s1}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantEvents: []string{domain.EventNameSessionStarted},
+		},
+
+		"end ended session should not publish session.ended again": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				for i := 0; i < 2; i++ {
+					if _, err := s.EndSession(ctx, session.EndSessionRequest{SessionID: s1}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantEvents: []string{domain.EventNameSessionEnded},
+		},
+
+		"start unknown session should return not found": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				_, err := s.StartSession(ctx, session.StartSessionRequest{SessionID: s2})
+				return err
+			},
+			wantErr:  true,
+			wantCode: errors.CodeNotFound,
+		},
+
+		"start and end question twice should publish question.started and question.ended once": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				for i := 0; i < 2; i++ {
+					if err := s.StartQuestion(ctx, session.StartQuestionRequest{SessionID: s1, QuestionID: "q1"}); err != nil {
+						return err
+					}
+				}
+				for i := 0; i < 2; i++ {
+					if err := s.EndQuestion(ctx, session.EndQuestionRequest{SessionID: s1, QuestionID: "q1"}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantEvents: []string{domain.EventNameQuestionStarted, domain.EventNameQuestionEnded},
+		},
+
+		"start unknown question should return not found": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				return s.StartQuestion(ctx, session.StartQuestionRequest{SessionID: s1, QuestionID: "q2"})
+			},
+			wantErr:  true,
+			wantCode: errors.CodeNotFound,
+		},
+
+		"start question of invalid session should return invalid argument": {
+			calls: func(ctx context.Context, s *session.Service) error {
+				return s.StartQuestion(ctx, session.StartQuestionRequest{SessionID: "s1", QuestionID: "q1"})
+			},
+			wantErr:  true,
+			wantCode: errors.CodeInvalidArgument,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu     sync.Mutex
+				events []string
+			)
+			record := func(_ context.Context, e event.Event) error {
+				mu.Lock()
+				events = append(events, e.Name())
+				mu.Unlock()
+				return nil
+			}
+
+			eb := event.NewBus()
+			eb.Subscribe(domain.EventNameSessionStarted, record)
+			eb.Subscribe(domain.EventNameSessionEnded, record)
+			eb.Subscribe(domain.EventNameQuestionStarted, record)
+			eb.Subscribe(domain.EventNameQuestionEnded, record)
+
+			s := session.NewService(session.Config{DB: makeDB(), EventBus: eb})
+
+			err := tt.calls(context.Background(), s)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errors.Convert(err).Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, eb.Stop(context.Background()))
+			assert.Equal(t, tt.wantEvents, events)
+		})
+	}
+}
+
+func TestService_JoinSession(t *testing.T) {
+	s := session.NewService(session.Config{DB: makeDB(), EventBus: event.NewBus()})
+	ctx := context.Background()
+
+	for _, u := range []string{"u1", "u2", "u1"} {
+		_, err := s.JoinSession(ctx, session.JoinSessionRequest{SessionID: s1, Username: u})
+		require.NoError(t, err)
+	}
+
+	ss, err := s.GetSession(ctx, session.GetSessionRequest{SessionID: s1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, ss.Participants, "joining a joined session should have no effect")
+
+	_, err = s.JoinSession(ctx, session.JoinSessionRequest{SessionID: s2, Username: "u1"})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodeNotFound, errors.Convert(err).Code)
+}
+
+// makeDB returns a database with the session s1 of the quiz master qm and the question q1.
+func makeDB() *fakeDB {
+	return &fakeDB{
+		sessions:  map[string]*fakeRow{s1: {}},
+		questions: map[string]*fakeRow{s1 + "/q1": {}},
+		users:     map[string][]string{},
+	}
+}
+
+type fakeRow struct {
+	started, ended bool
+}
+
+// fakeDB runs the statements of the session service on in-memory tables.
+type fakeDB struct {
+	mu        sync.Mutex
+	sessions  map[string]*fakeRow
+	questions map[string]*fakeRow
+	users     map[string][]string
+}
+
+func (*fakeDB) Begin(context.Context) (pgx.Tx, error) {
+	return nil, stderrors.New("transactions are not supported")
+}
+
+func (db *fakeDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// update sets the column of the row if it isn't set yet, like the "IS NULL" conditions.
+	update := func(r *fakeRow, ok bool, sql string) pgconn.CommandTag {
+		switch {
+		case !ok:
+		case strings.Contains(sql, "SET start_time") && !r.started:
+			r.started = true
+			return pgconn.NewCommandTag("UPDATE 1")
+		case strings.Contains(sql, "SET end_time") && !r.ended:
+			r.ended = true
+			return pgconn.NewCommandTag("UPDATE 1")
+		}
+		return pgconn.NewCommandTag("UPDATE 0")
+	}
+
+	switch {
+	case strings.HasPrefix(sql, "UPDATE sessions_questions"):
+		r, ok := db.questions[fmt.Sprint(args[0], "/", args[1])]
+		return update(r, ok, sql), nil
+
+	case strings.HasPrefix(sql, "UPDATE sessions"):
+		r, ok := db.sessions[fmt.Sprint(args[0])]
+		return update(r, ok, sql), nil
+
+	case strings.HasPrefix(sql, "INSERT INTO sessions_users"):
+		id, u := fmt.Sprint(args[0]), fmt.Sprint(args[1])
+		for _, v := range db.users[id] {
+			if v == u {
+				return pgconn.NewCommandTag("INSERT 0 0"), nil
+			}
+		}
+		db.users[id] = append(db.users[id], u)
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	}
+
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected statement: %s", sql)
+}
+
+func (db *fakeDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	id := fmt.Sprint(args[0])
+
+	switch {
+	case strings.HasPrefix(sql, "SELECT question_id"):
+		var values []any
+		for k := range db.questions {
+			if q, ok := strings.CutPrefix(k, id+"/"); ok {
+				values = append(values, q)
+			}
+		}
+		return &fakeRows{values: values}, nil
+
+	case strings.HasPrefix(sql, "SELECT username"):
+		var values []any
+		for _, u := range db.users[id] {
+			values = append(values, u)
+		}
+		return &fakeRows{values: values}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected query: %s", sql)
+}
+
+func (db *fakeDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(sql, "SELECT quiz_master"):
+		if _, ok := db.sessions[fmt.Sprint(args[0])]; !ok {
+			return &fakeRows{err: pgx.ErrNoRows}
+		}
+		return &fakeRows{values: []any{"qm"}}
+
+	case strings.HasPrefix(sql, "SELECT EXISTS"):
+		_, ok := db.questions[fmt.Sprint(args[0], "/", args[1])]
+		return &fakeRows{values: []any{ok}}
+	}
+
+	return &fakeRows{err: fmt.Errorf("unexpected query: %s", sql)}
+}
+
+// fakeRows are rows of a single column, they are also a row scanning the first value.
+type fakeRows struct {
+	pgx.Rows
+	values []any
+	i      int
+	err    error
+}
+
+func (r *fakeRows) Next() bool {
+	r.i++
+	return r.i <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.i == 0 {
+		r.i = 1
+	}
+
+	switch d := dest[0].(type) {
+	case *string:
+		*d = r.values[r.i-1].(string)
+	case *bool:
+		*d = r.values[r.i-1].(bool)
+	default:
+		return fmt.Errorf("unexpected destination: %T", d)
+	}
+
+	return nil
+}
+
+func (*fakeRows) Close() {}
+
+func (r *fakeRows) Err() error { return r.err }
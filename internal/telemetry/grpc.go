@@ -14,10 +14,22 @@ func GRPCServerInterceptor() grpc.ServerOption {
 	}
 
 	return grpc.ChainUnaryInterceptor(
+		tracingUnaryServerInterceptor(),
 		logging.UnaryServerInterceptor(grpcServerLogger(slog.Default()), opts...),
 	)
 }
 
+func GRPCServerStreamInterceptor() grpc.ServerOption {
+	opts := []logging.Option{
+		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+	}
+
+	return grpc.ChainStreamInterceptor(
+		tracingStreamServerInterceptor(),
+		logging.StreamServerInterceptor(grpcServerLogger(slog.Default()), opts...),
+	)
+}
+
 func grpcServerLogger(l *slog.Logger) logging.Logger {
 	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
 		l.Log(ctx, slog.Level(lvl), msg, fields...)
@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/victornm/equiz/internal/event"
+)
+
+// RequestIDHeader is the header of the request ID, it is generated if the caller doesn't send one,
+// and it is sent back in the response. The events published while handling the request are correlated by it.
+const RequestIDHeader = "X-Request-Id"
+
+const tracerName = "github.com/victornm/equiz/internal/telemetry"
+
+// InitTracing sets the global tracer provider and the W3C trace context propagator,
+// so the requests and the events they publish have trace IDs, continuing the traces of the callers.
+func InitTracing() {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// serverContext starts the span of a request, and correlates the events published while handling it by the request ID.
+func serverContext(ctx context.Context, name string, carrier propagation.TextMapCarrier, requestID string) (context.Context, trace.Span, string) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	return event.WithCorrelationID(ctx, requestID), span, requestID
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func tracingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		ctx, span, id := serverContext(ctx, info.FullMethod, metadataCarrier(md), first(md.Get(RequestIDHeader)))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+		resp, err := handler(ctx, req)
+		endSpan(span, err)
+
+		return resp, err
+	}
+}
+
+func tracingStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+
+		ctx, span, id := serverContext(ss.Context(), info.FullMethod, metadataCarrier(md), first(md.Get(RequestIDHeader)))
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+
+		wrapped := middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+
+		err := handler(srv, wrapped)
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+// Middleware traces the HTTP requests, and correlates the events published while handling them by the request IDs.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.FullPath()
+		if name == "" {
+			name = "unmatched"
+		}
+
+		ctx, span, id := serverContext(c.Request.Context(), c.Request.Method+" "+name,
+			propagation.HeaderCarrier(c.Request.Header), c.GetHeader(RequestIDHeader))
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		var err error
+		if e := c.Errors.Last(); e != nil {
+			err = e
+		}
+		endSpan(span, err)
+	}
+}
+
+// metadataCarrier adapts the gRPC metadata to the propagators, the keys of the metadata are lowercase.
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	return first(metadata.MD(m).Get(key))
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,80 @@
+package telemetry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/event"
+	"github.com/victornm/equiz/internal/telemetry"
+)
+
+type testEvent struct{}
+
+func (testEvent) Name() string { return "test" }
+
+func TestMiddleware(t *testing.T) {
+	telemetry.InitTracing()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := map[string]struct {
+		header        http.Header
+		correlationID string
+		traceID       string
+	}{
+		"request ID and trace of the caller": {
+			header: http.Header{
+				telemetry.RequestIDHeader: {"r1"},
+				"Traceparent":             {"00-" + traceID + "-00f067aa0ba902b7-01"},
+			},
+			correlationID: "r1",
+			traceID:       traceID,
+		},
+		"generated request ID and trace": {
+			header: http.Header{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			envelopes := make(chan event.Envelope, 1)
+
+			b := event.NewBus()
+			b.Subscribe("test", func(ctx context.Context, _ event.Event) error {
+				env, _ := event.EnvelopeFromContext(ctx)
+				envelopes <- env
+				return nil
+			})
+
+			e := gin.New()
+			e.Use(telemetry.Middleware())
+			e.GET("/", func(c *gin.Context) {
+				require.NoError(t, b.Publish(c.Request.Context(), testEvent{}))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header = tt.header
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			require.NoError(t, b.Stop(context.Background()))
+
+			env := <-envelopes
+			requestID := rec.Header().Get(telemetry.RequestIDHeader)
+			require.NotEmpty(t, requestID)
+			require.Equal(t, requestID, env.CorrelationID)
+			require.NotEmpty(t, env.TraceID)
+
+			if tt.correlationID != "" {
+				require.Equal(t, tt.correlationID, env.CorrelationID)
+			}
+			if tt.traceID != "" {
+				require.Equal(t, tt.traceID, env.TraceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Delivery is an attempt to deliver an event to a subscription.
+type Delivery struct {
+	// ID identifies the delivery, it is the same for all attempts.
+	ID         string
+	Attempt    int
+	EventID    string
+	Event      string
+	URL        string
+	StatusCode int
+	// Error is the reason of the failed attempt, empty if it succeeded.
+	Error      string
+	Duration   time.Duration
+	CreateTime time.Time
+}
+
+// DeliveryLog records the attempts of the deliveries.
+type DeliveryLog interface {
+	Record(ctx context.Context, d Delivery) error
+}
+
+// PostgresLog records the attempts to the webhook_deliveries table.
+type PostgresLog struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresLog(db *pgxpool.Pool) *PostgresLog {
+	return &PostgresLog{db: db}
+}
+
+func (l *PostgresLog) Record(ctx context.Context, d Delivery) error {
+	const insStmt = `INSERT INTO webhook_deliveries
+		(delivery_id, attempt, event_id, event, url, status_code, error, duration_ms, create_time)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	_, err := l.db.Exec(ctx, insStmt,
+		d.ID, d.Attempt, d.EventID, d.Event, d.URL, d.StatusCode, d.Error, d.Duration.Milliseconds(), d.CreateTime,
+	)
+	if err != nil {
+		return fmt.Errorf("insert delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var droppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "equiz",
+	Subsystem: "webhook",
+	Name:      "dropped_total",
+	Help:      "Total number of deliveries dropped because the queue of the subscription is full by event name.",
+}, []string{"event"})
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sign returns the signature header of the payload: t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<payload>">.
+// The time is signed too, so the receivers can reject the replayed deliveries.
+func Sign(secret string, t time.Time, payload []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac(secret, ts, payload)))
+}
+
+// Verify verifies the signature header of the payload, the signature must be signed within the tolerance.
+func Verify(secret, header string, payload []byte, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return fmt.Errorf("invalid signature header: %s", header)
+	}
+
+	if d := time.Since(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return fmt.Errorf("signature is expired: t=%s", ts)
+	}
+
+	want, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(want, mac(secret, ts, payload)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+func mac(secret, ts string, payload []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return h.Sum(nil)
+}
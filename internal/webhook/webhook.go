@@ -0,0 +1,521 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/event"
+	"github.com/victornm/equiz/internal/leaderboard"
+	"github.com/victornm/equiz/internal/session"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	defaultTimeout     = 10 * time.Second
+	defaultQueueSize   = 1000
+	maxBackoff         = 30 * time.Second
+)
+
+const (
+	HeaderEvent     = "X-Equiz-Event"
+	HeaderDelivery  = "X-Equiz-Delivery"
+	HeaderSignature = "X-Equiz-Signature"
+)
+
+type Config struct {
+	EventBus     *event.Bus
+	Sessions     Sessions
+	Leaderboards Leaderboards
+	Log          DeliveryLog
+	Client       *http.Client
+
+	Subscriptions []Subscription
+	// MaxAttempts is the maximum number of attempts of a delivery, defaults to 5.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it is doubled for each retry. Defaults to 1s.
+	Backoff time.Duration
+	// Timeout is the timeout of each attempt, defaults to 10s.
+	Timeout time.Duration
+	// QueueSize is the maximum number of pending score.updated deliveries of each subscription,
+	// the new deliveries are dropped if the queue is full. Defaults to 1000.
+	QueueSize int
+}
+
+// Subscription subscribes a URL to the events of the sessions of a quiz master.
+type Subscription struct {
+	// QuizMaster filters the sessions by their quiz master, empty means all sessions of the tenant.
+	QuizMaster string
+	URL        string
+	// Secret signs the payloads, so the receiver can verify them.
+	Secret string
+	// Events are the names of the subscribed events, empty means all events.
+	Events []string
+}
+
+func (s Subscription) matches(quizMaster, event string) bool {
+	if s.QuizMaster != "" && s.QuizMaster != quizMaster {
+		return false
+	}
+
+	return len(s.Events) == 0 || slices.Contains(s.Events, event)
+}
+
+type Sessions interface {
+	GetSession(ctx context.Context, req session.GetSessionRequest) (*domain.Session, error)
+}
+
+type Leaderboards interface {
+	GetLeaderboard(ctx context.Context, req leaderboard.GetLeaderboardRequest) (*domain.Leaderboard, error)
+}
+
+// Service delivers the session lifecycle events to the subscribed URLs:
+// session.ended, the final leaderboard.updated when the session ends, and score.updated.
+type Service struct {
+	ss     Sessions
+	ls     Leaderboards
+	log    DeliveryLog
+	client *http.Client
+
+	subs        []Subscription
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+
+	// quizMasters caches the quiz masters by the sessions, they never change.
+	quizMasters sync.Map
+
+	// queues are the pending score.updated deliveries by the subscriptions,
+	// each one is delivered by its own worker so a slow URL doesn't hold back the others nor the event bus.
+	queues  []chan queuedDelivery
+	mu      sync.RWMutex
+	stopped bool
+	workers sync.WaitGroup
+	// ctx cancels the pending deliveries when Stop times out.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type queuedDelivery struct {
+	ctx  context.Context
+	p    Payload
+	body []byte
+}
+
+func NewService(c Config) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		ss:          c.Sessions,
+		ls:          c.Leaderboards,
+		log:         c.Log,
+		client:      c.Client,
+		subs:        c.Subscriptions,
+		maxAttempts: c.MaxAttempts,
+		backoff:     c.Backoff,
+		timeout:     c.Timeout,
+	}
+
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = defaultMaxAttempts
+	}
+	if s.backoff <= 0 {
+		s.backoff = defaultBackoff
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultTimeout
+	}
+	queueSize := c.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	if len(s.subs) == 0 {
+		return s
+	}
+
+	// The deliveries of a session are in order, and a delivery can take all of its attempts.
+	// A session.ended event has 2 deliveries: session.ended and the final leaderboard.updated.
+	event.Subscribe(c.EventBus, s.HandleSessionEnded, event.WithTimeout(2*s.maxDeliveryTime()),
+		event.PartitionBy(func(e domain.EventSessionEnded) string {
+			return e.Session.SessionID
+		}),
+	)
+
+	// The score.updated events are only queued by the handler, the queues keep them in order.
+	s.queues = make([]chan queuedDelivery, len(s.subs))
+	for i, sub := range s.subs {
+		s.queues[i] = make(chan queuedDelivery, queueSize)
+
+		s.workers.Add(1)
+		go s.work(sub, s.queues[i])
+	}
+	event.Subscribe(c.EventBus, s.HandleScoreUpdated,
+		event.PartitionBy(func(e domain.EventScoreUpdated) string {
+			return e.Score.SessionID
+		}),
+	)
+
+	return s
+}
+
+// Stop stops queueing the score.updated deliveries and waits for the queued ones,
+// the remaining deliveries are canceled if the context is done first.
+func (s *Service) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		for _, q := range s.queues {
+			close(q)
+		}
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return fmt.Errorf("webhook: stop: %w", ctx.Err())
+	}
+}
+
+type (
+	// Payload is the JSON body of the deliveries.
+	Payload struct {
+		// ID identifies the event, the receivers can use it to deduplicate the deliveries.
+		ID         string    `json:"id"`
+		Event      string    `json:"event"`
+		OccurredAt time.Time `json:"occurred_at"`
+		Data       any       `json:"data"`
+	}
+
+	SessionEnded struct {
+		SessionID  string `json:"session_id"`
+		QuizMaster string `json:"quiz_master"`
+	}
+
+	LeaderboardUpdated struct {
+		SessionID string             `json:"session_id"`
+		Entries   []LeaderboardEntry `json:"entries"`
+		// Final is true if the session is ended, only the final leaderboard is delivered.
+		Final bool `json:"final"`
+	}
+
+	LeaderboardEntry struct {
+		Username string  `json:"username"`
+		Score    float64 `json:"score"`
+	}
+
+	ScoreUpdated struct {
+		SessionID  string    `json:"session_id"`
+		Username   string    `json:"username"`
+		TotalScore string    `json:"total_score"`
+		UpdateTime time.Time `json:"update_time"`
+	}
+)
+
+// HandleSessionEnded delivers session.ended, then the final leaderboard of the session.
+func (s *Service) HandleSessionEnded(ctx context.Context, e domain.EventSessionEnded) error {
+	ss := e.Session
+	defer s.quizMasters.Delete(ss.SessionID)
+
+	if err := s.deliver(ctx, ss.QuizMaster, newPayload(ctx, e.Name(), SessionEnded{
+		SessionID:  ss.SessionID,
+		QuizMaster: ss.QuizMaster,
+	})); err != nil {
+		return err
+	}
+
+	if !s.subscribed(ss.QuizMaster, domain.EventNameLeaderboardUpdated) {
+		return nil
+	}
+
+	// A session without any scores has no leaderboard, its final leaderboard is empty.
+	l, err := s.ls.GetLeaderboard(ctx, leaderboard.GetLeaderboardRequest{SessionID: ss.SessionID})
+	switch {
+	case err != nil && errors.Convert(err).Code == errors.CodeNotFound:
+		l = &domain.Leaderboard{SessionID: ss.SessionID}
+	case err != nil:
+		return fmt.Errorf("webhook: get final leaderboard: session=%s: %w", ss.SessionID, err)
+	}
+
+	data := LeaderboardUpdated{
+		SessionID: ss.SessionID,
+		Entries:   make([]LeaderboardEntry, 0, len(l.Entries)),
+		Final:     true,
+	}
+	for _, entry := range l.Entries {
+		data.Entries = append(data.Entries, LeaderboardEntry{Username: entry.Username, Score: entry.Score})
+	}
+
+	// The final leaderboard is not an event of the bus, so it has its own ID.
+	p := newPayload(ctx, domain.EventNameLeaderboardUpdated, data)
+	p.ID = uuid.NewString()
+
+	return s.deliver(ctx, ss.QuizMaster, p)
+}
+
+// HandleScoreUpdated queues score.updated to the matching subscriptions,
+// it is dropped for the subscriptions whose queues are full.
+func (s *Service) HandleScoreUpdated(ctx context.Context, e domain.EventScoreUpdated) error {
+	sc := e.Score
+
+	// Avoid getting the quiz master of the session if no one subscribes to the event.
+	if !slices.ContainsFunc(s.subs, func(sub Subscription) bool {
+		return len(sub.Events) == 0 || slices.Contains(sub.Events, e.Name())
+	}) {
+		return nil
+	}
+
+	quizMaster, err := s.quizMaster(ctx, sc.SessionID)
+	if err != nil {
+		return err
+	}
+
+	p := newPayload(ctx, e.Name(), ScoreUpdated{
+		SessionID:  sc.SessionID,
+		Username:   sc.Username,
+		TotalScore: sc.TotalScore.String(),
+		UpdateTime: sc.UpdateTime,
+	})
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal %s: %w", p.Event, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.stopped {
+		return fmt.Errorf("webhook: queue %s: service is stopped", p.Event)
+	}
+
+	// The deliveries outlive the handler, but keep the values of its context, e.g. the trace.
+	d := queuedDelivery{ctx: context.WithoutCancel(ctx), p: p, body: body}
+	for i, sub := range s.subs {
+		if !sub.matches(quizMaster, p.Event) {
+			continue
+		}
+
+		select {
+		case s.queues[i] <- d:
+		default:
+			droppedTotal.WithLabelValues(p.Event).Inc()
+			slog.WarnContext(ctx, "webhook: queue is full, delivery dropped", "event", p.Event, "event_id", p.ID, "url", sub.URL)
+		}
+	}
+
+	return nil
+}
+
+// work delivers the queued deliveries to the subscription in order, until the queue is closed.
+func (s *Service) work(sub Subscription, queue <-chan queuedDelivery) {
+	defer s.workers.Done()
+
+	for d := range queue {
+		ctx, cancel := context.WithCancel(d.ctx)
+		stop := context.AfterFunc(s.ctx, cancel)
+
+		if err := s.deliverTo(ctx, sub, d.p, d.body); err != nil {
+			slog.ErrorContext(ctx, "webhook: delivery failed", "event", d.p.Event, "event_id", d.p.ID, "error", err)
+		}
+
+		stop()
+		cancel()
+	}
+}
+
+func (s *Service) quizMaster(ctx context.Context, sessionID string) (string, error) {
+	if qm, ok := s.quizMasters.Load(sessionID); ok {
+		return qm.(string), nil
+	}
+
+	ss, err := s.ss.GetSession(ctx, session.GetSessionRequest{SessionID: sessionID})
+	if err != nil {
+		return "", fmt.Errorf("webhook: get session: session=%s: %w", sessionID, err)
+	}
+
+	s.quizMasters.Store(sessionID, ss.QuizMaster)
+	return ss.QuizMaster, nil
+}
+
+func (s *Service) subscribed(quizMaster, event string) bool {
+	return slices.ContainsFunc(s.subs, func(sub Subscription) bool {
+		return sub.matches(quizMaster, event)
+	})
+}
+
+func newPayload(ctx context.Context, name string, data any) Payload {
+	p := Payload{
+		Event:      name,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+
+	if env, ok := event.EnvelopeFromContext(ctx); ok {
+		p.ID = env.ID
+		p.OccurredAt = env.OccurredAt
+	}
+
+	if p.ID == "" {
+		p.ID = uuid.NewString()
+	}
+
+	return p
+}
+
+// deliver delivers the payload to the matching subscriptions concurrently,
+// it returns the errors of the deliveries which failed after all attempts.
+func (s *Service) deliver(ctx context.Context, quizMaster string, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal %s: %w", p.Event, err)
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		eg   errgroup.Group
+	)
+
+	for _, sub := range s.subs {
+		if !sub.matches(quizMaster, p.Event) {
+			continue
+		}
+
+		eg.Go(func() error {
+			if err := s.deliverTo(ctx, sub, p, body); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+	return stderrors.Join(errs...)
+}
+
+// deliverTo posts the payload to the subscription, retrying with exponential backoff.
+func (s *Service) deliverTo(ctx context.Context, sub Subscription, p Payload, body []byte) error {
+	d := Delivery{
+		ID:      uuid.NewString(),
+		EventID: p.ID,
+		Event:   p.Event,
+		URL:     sub.URL,
+	}
+
+	backoff := s.backoff
+	for d.Attempt = 1; ; d.Attempt++ {
+		retryable := s.attempt(ctx, sub, &d, body)
+		s.record(ctx, d)
+
+		if d.Error == "" {
+			return nil
+		}
+
+		if !retryable || d.Attempt >= s.maxAttempts {
+			return fmt.Errorf("webhook: deliver %s to %s: attempt=%d: %s", p.Event, sub.URL, d.Attempt, d.Error)
+		}
+
+		// The jitter spreads out the retries of the deliveries failed at the same time.
+		wait := time.Duration(rand.Int64N(int64(backoff))) + backoff/2
+		backoff = min(backoff*2, maxBackoff)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook: deliver %s to %s: attempt=%d: %w", p.Event, sub.URL, d.Attempt, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// attempt posts the payload once, and sets the result to the delivery.
+// It returns whether the failure is retryable.
+func (s *Service) attempt(ctx context.Context, sub Subscription, d *Delivery, body []byte) bool {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	d.StatusCode, d.Error, d.Duration = 0, "", 0
+	defer func() { d.Duration = time.Since(start) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		d.Error = err.Error()
+		return false
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, time.Now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		d.Error = err.Error()
+		return true
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+	d.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false
+	}
+
+	d.Error = "unexpected status: " + strconv.Itoa(resp.StatusCode)
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+}
+
+func (s *Service) record(ctx context.Context, d Delivery) {
+	if s.log == nil {
+		return
+	}
+
+	d.CreateTime = time.Now()
+	if err := s.log.Record(ctx, d); err != nil {
+		slog.ErrorContext(ctx, "webhook: record delivery failed", "delivery", d.ID, "attempt", d.Attempt, "error", err)
+	}
+}
+
+// maxDeliveryTime is the maximum time of a delivery with all of its attempts.
+func (s *Service) maxDeliveryTime() time.Duration {
+	total := time.Duration(s.maxAttempts) * s.timeout
+	for i, b := 1, s.backoff; i < s.maxAttempts; i, b = i+1, min(b*2, maxBackoff) {
+		total += b * 3 / 2
+	}
+
+	return total
+}
@@ -0,0 +1,360 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/victornm/equiz/internal/domain"
+	"github.com/victornm/equiz/internal/errors"
+	"github.com/victornm/equiz/internal/event"
+	"github.com/victornm/equiz/internal/leaderboard"
+	"github.com/victornm/equiz/internal/session"
+	"github.com/victornm/equiz/internal/webhook"
+)
+
+const secret = "secret"
+
+func TestService_Deliver(t *testing.T) {
+	type (
+		received struct {
+			event   string
+			payload webhook.Payload
+		}
+
+		outputs struct {
+			err        error
+			received   []received
+			deliveries []webhook.Delivery
+		}
+	)
+
+	sessionEnded := func(s *webhook.Service) error {
+		return s.HandleSessionEnded(context.Background(), domain.EventSessionEnded{
+			Session: domain.Session{SessionID: "s1", QuizMaster: "qm1"},
+		})
+	}
+
+	scoreUpdated := func(s *webhook.Service) error {
+		return s.HandleScoreUpdated(context.Background(), domain.EventScoreUpdated{
+			Score: domain.Score{SessionID: "s1", Username: "u1", TotalScore: decimal.NewFromInt(10)},
+		})
+	}
+
+	tests := map[string]struct {
+		subscription webhook.Subscription
+		statuses     []int
+		handle       func(s *webhook.Service) error
+		assert       func(t *testing.T, out outputs)
+	}{
+		"session ended with final leaderboard": {
+			subscription: webhook.Subscription{QuizMaster: "qm1"},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Len(t, out.received, 2)
+				require.Equal(t, domain.EventNameSessionEnded, out.received[0].event)
+				require.Equal(t, domain.EventNameLeaderboardUpdated, out.received[1].event)
+				require.Equal(t, map[string]any{
+					"session_id": "s1",
+					"final":      true,
+					"entries":    []any{map[string]any{"username": "u1", "score": 10.0}},
+				}, out.received[1].payload.Data)
+			},
+		},
+		"session ended without scores with empty final leaderboard": {
+			subscription: webhook.Subscription{Events: []string{domain.EventNameLeaderboardUpdated}},
+			handle: func(s *webhook.Service) error {
+				return s.HandleSessionEnded(context.Background(), domain.EventSessionEnded{
+					Session: domain.Session{SessionID: "s2", QuizMaster: "qm1"},
+				})
+			},
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Len(t, out.received, 1)
+				require.Equal(t, map[string]any{
+					"session_id": "s2",
+					"final":      true,
+					"entries":    []any{},
+				}, out.received[0].payload.Data)
+			},
+		},
+		"filter by events": {
+			subscription: webhook.Subscription{Events: []string{domain.EventNameLeaderboardUpdated}},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Len(t, out.received, 1)
+				require.Equal(t, domain.EventNameLeaderboardUpdated, out.received[0].event)
+			},
+		},
+		"filter by quiz master": {
+			subscription: webhook.Subscription{QuizMaster: "qm2"},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Empty(t, out.received)
+			},
+		},
+		"score updated of the sessions of the quiz master": {
+			subscription: webhook.Subscription{QuizMaster: "qm1", Events: []string{domain.EventNameScoreUpdated}},
+			handle:       scoreUpdated,
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Len(t, out.received, 1)
+				require.Equal(t, "10", out.received[0].payload.Data.(map[string]any)["total_score"])
+			},
+		},
+		"retry server errors": {
+			subscription: webhook.Subscription{Events: []string{domain.EventNameSessionEnded}},
+			statuses:     []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.NoError(t, out.err)
+				require.Len(t, out.received, 3)
+				require.Len(t, out.deliveries, 3)
+				for i, d := range out.deliveries {
+					require.Equal(t, i+1, d.Attempt)
+					require.Equal(t, out.deliveries[0].ID, d.ID)
+				}
+				require.Equal(t, http.StatusOK, out.deliveries[2].StatusCode)
+				require.Empty(t, out.deliveries[2].Error)
+			},
+		},
+		"give up after max attempts": {
+			subscription: webhook.Subscription{Events: []string{domain.EventNameSessionEnded}},
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.Error(t, out.err)
+				require.Len(t, out.deliveries, 3)
+			},
+		},
+		"don't retry client errors": {
+			subscription: webhook.Subscription{Events: []string{domain.EventNameSessionEnded}},
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			handle:       sessionEnded,
+			assert: func(t *testing.T, out outputs) {
+				require.Error(t, out.err)
+				require.Len(t, out.deliveries, 1)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu   sync.Mutex
+				out  outputs
+				errs []error
+			)
+
+			// The handler runs on the server goroutines, its errors are asserted by the test goroutine.
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p, err := readPayload(r)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err != nil {
+					errs = append(errs, err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				out.received = append(out.received, received{event: p.Event, payload: p})
+
+				status := http.StatusOK
+				if n := len(out.received); n <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+				w.WriteHeader(status)
+			}))
+			t.Cleanup(srv.Close)
+
+			eb := event.NewBus()
+			t.Cleanup(func() { _ = eb.Stop(context.Background()) })
+
+			sub := tt.subscription
+			sub.URL = srv.URL
+			sub.Secret = secret
+
+			log := &memoryLog{}
+			s := webhook.NewService(webhook.Config{
+				EventBus:      eb,
+				Sessions:      fakeSessions{"s1": {SessionID: "s1", QuizMaster: "qm1"}},
+				Leaderboards:  fakeLeaderboards{"s1": {SessionID: "s1", Entries: []domain.LeaderboardEntry{{Username: "u1", Score: 10}}}},
+				Log:           log,
+				Subscriptions: []webhook.Subscription{sub},
+				MaxAttempts:   3,
+				Backoff:       time.Millisecond,
+				Timeout:       time.Second,
+			})
+
+			out.err = tt.handle(s)
+			require.NoError(t, s.Stop(context.Background()))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.Empty(t, errs)
+			out.deliveries = log.deliveries
+			tt.assert(t, out)
+		})
+	}
+}
+
+func TestService_HandleScoreUpdated_Overflow(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+		errs     []error
+		started  = make(chan struct{}, 1)
+		release  = make(chan struct{})
+	)
+
+	// The endpoint is stuck until released, so the queued deliveries are pending.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := readPayload(r)
+
+		mu.Lock()
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			received = append(received, p.Data.(map[string]any)["username"].(string))
+		}
+		mu.Unlock()
+
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+
+	eb := event.NewBus()
+	t.Cleanup(func() { _ = eb.Stop(context.Background()) })
+
+	s := webhook.NewService(webhook.Config{
+		EventBus:      eb,
+		Sessions:      fakeSessions{"s1": {SessionID: "s1", QuizMaster: "qm1"}},
+		Subscriptions: []webhook.Subscription{{URL: srv.URL, Secret: secret, Events: []string{domain.EventNameScoreUpdated}}},
+		MaxAttempts:   1,
+		Timeout:       5 * time.Second,
+		QueueSize:     1,
+	})
+
+	scoreUpdated := func(username string) {
+		done := make(chan error, 1)
+		go func() {
+			done <- s.HandleScoreUpdated(context.Background(), domain.EventScoreUpdated{
+				Score: domain.Score{SessionID: "s1", Username: username, TotalScore: decimal.NewFromInt(1)},
+			})
+		}()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("the handler should not wait for the deliveries")
+		}
+	}
+
+	scoreUpdated("u1")
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("u1 not delivered")
+	}
+
+	// u1 is being delivered, u2 fills the queue and u3 is dropped.
+	scoreUpdated("u2")
+	scoreUpdated("u3")
+
+	close(release)
+	require.NoError(t, s.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Empty(t, errs)
+	require.Equal(t, []string{"u1", "u2"}, received)
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"id":"1"}`)
+	now := time.Now()
+
+	require.NoError(t, webhook.Verify(secret, webhook.Sign(secret, now, payload), payload, time.Minute))
+	require.Error(t, webhook.Verify("wrong", webhook.Sign(secret, now, payload), payload, time.Minute))
+	require.Error(t, webhook.Verify(secret, webhook.Sign(secret, now, payload), []byte(`{"id":"2"}`), time.Minute))
+	require.Error(t, webhook.Verify(secret, webhook.Sign(secret, now.Add(-time.Hour), payload), payload, time.Minute))
+	require.Error(t, webhook.Verify(secret, "v1=abc", payload, time.Minute))
+}
+
+// readPayload reads and verifies the payload of a delivery.
+func readPayload(r *http.Request) (webhook.Payload, error) {
+	var p webhook.Payload
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return p, err
+	}
+
+	if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderSignature), body, time.Minute); err != nil {
+		return p, err
+	}
+
+	if err := json.Unmarshal(body, &p); err != nil {
+		return p, err
+	}
+
+	if event := r.Header.Get(webhook.HeaderEvent); event != p.Event {
+		return p, fmt.Errorf("unexpected %s header: %q, want %q", webhook.HeaderEvent, event, p.Event)
+	}
+
+	return p, nil
+}
+
+type memoryLog struct {
+	mu         sync.Mutex
+	deliveries []webhook.Delivery
+}
+
+func (l *memoryLog) Record(_ context.Context, d webhook.Delivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.deliveries = append(l.deliveries, d)
+	return nil
+}
+
+type fakeSessions map[string]*domain.Session
+
+func (f fakeSessions) GetSession(_ context.Context, req session.GetSessionRequest) (*domain.Session, error) {
+	return f[req.SessionID], nil
+}
+
+type fakeLeaderboards map[string]*domain.Leaderboard
+
+func (f fakeLeaderboards) GetLeaderboard(_ context.Context, req leaderboard.GetLeaderboardRequest) (*domain.Leaderboard, error) {
+	l, ok := f[req.SessionID]
+	if !ok {
+		return nil, errors.New(errors.CodeNotFound, errors.WithReason(errors.ReasonLeaderboardNotFound))
+	}
+
+	return l, nil
+}
@@ -10,12 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/victornm/equiz/internal/api"
@@ -25,6 +27,9 @@ import (
 
 const (
 	addr = "localhost:8081"
+
+	// secret is the HMAC secret of the tokens in config/local.yaml.
+	secret = "local-secret"
 )
 
 func TestQuiz(t *testing.T) {
@@ -46,28 +51,49 @@ func TestQuiz(t *testing.T) {
 	// Prepare Redis subscriber
 	subscribeAsUser(t, makeRedis(t), wg, "u1")
 
-	// Create new session
+	qmCtx := withToken(t, ctx, quizMaster)
+
+	// Create, join and start new session
 	{
-		resp, err := qc.CreateSession(ctx, &equizv1.CreateSessionRequest{
+		resp, err := qc.CreateSession(qmCtx, &equizv1.CreateSessionRequest{
 			RequestId:   uuid.New().String(),
-			QuizMaster:  quizMaster,
 			QuestionIds: questions,
 		})
 		require.NoError(t, err)
 		session = resp.Session.SessionId
+
+		for _, u := range users {
+			_, err = qc.JoinSession(withToken(t, ctx, u), &equizv1.JoinSessionRequest{
+				RequestId: uuid.New().String(),
+				SessionId: session,
+			})
+			require.NoError(t, err)
+		}
+
+		_, err = qc.StartSession(qmCtx, &equizv1.StartSessionRequest{
+			RequestId: uuid.New().String(),
+			SessionId: session,
+		})
+		require.NoError(t, err)
 	}
 
 	// For each question, all users will submit answers concurrently
 	for _, q := range questions {
 		t.Logf("Starting question %q", q)
+		_, err := qc.StartQuestion(qmCtx, &equizv1.StartQuestionRequest{
+			RequestId:  uuid.New().String(),
+			SessionId:  session,
+			QuestionId: q,
+		})
+		require.NoError(t, err)
+
 		var eg errgroup.Group
 		for _, u := range users {
 			u := u
 			eg.Go(func() error {
-				resp, err := qc.SubmitAnswer(ctx, &equizv1.SubmitAnswerRequest{
+				resp, err := qc.SubmitAnswer(withToken(t, ctx, u), &equizv1.SubmitAnswerRequest{
 					RequestId:  uuid.New().String(),
 					SessionId:  session,
-					Username:   u,
 					QuestionId: q,
 					Answer:     "A",
 					SubmitTime: timestamppb.Now(),
@@ -81,15 +107,39 @@ func TestQuiz(t *testing.T) {
 			})
 		}
 
-		err := eg.Wait()
+		err = eg.Wait()
 		require.NoError(t, err)
 
 		time.Sleep(2 * time.Second)
+
+		_, err = qc.EndQuestion(qmCtx, &equizv1.EndQuestionRequest{
+			RequestId:  uuid.New().String(),
+			SessionId:  session,
+			QuestionId: q,
+		})
+		require.NoError(t, err)
 	}
 
+	_, err := qc.EndSession(qmCtx, &equizv1.EndSessionRequest{
+		RequestId: uuid.New().String(),
+		SessionId: session,
+	})
+	require.NoError(t, err)
+
 	wg.Wait()
 }
 
+// withToken returns a context authenticating the gRPC calls as the user.
+func withToken(t *testing.T, ctx context.Context, user string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   user,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
 func makeQuizClient(t *testing.T) equizv1.QuizServiceClient {
 	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	require.NoError(t, err)